@@ -3,15 +3,19 @@ module github.com/m-mizutani/octap
 go 1.24.2
 
 require (
+	github.com/expr-lang/expr v1.17.8
 	github.com/fatih/color v1.18.0
 	github.com/go-git/go-git/v5 v5.16.2
+	github.com/godbus/dbus/v5 v5.1.0
 	github.com/google/go-github/v74 v74.0.0
 	github.com/m-mizutani/ctxlog v0.2.0
 	github.com/m-mizutani/goerr/v2 v2.0.0-beta.4
 	github.com/m-mizutani/gt v0.1.0
+	github.com/mattn/go-isatty v0.0.20
 	github.com/urfave/cli/v3 v3.4.1
 	go.uber.org/goleak v1.3.0
 	golang.org/x/oauth2 v0.31.0
+	golang.org/x/term v0.33.0
 	gopkg.in/yaml.v3 v3.0.1
 )
 
@@ -30,7 +34,6 @@ require (
 	github.com/jbenet/go-context v0.0.0-20150711004518-d14ea06fba99 // indirect
 	github.com/kevinburke/ssh_config v1.2.0 // indirect
 	github.com/mattn/go-colorable v0.1.13 // indirect
-	github.com/mattn/go-isatty v0.0.20 // indirect
 	github.com/pjbgf/sha1cd v0.3.2 // indirect
 	github.com/sergi/go-diff v1.3.2-0.20230802210424-5b0b94c5c0d3 // indirect
 	github.com/skeema/knownhosts v1.3.1 // indirect
@@ -14,6 +14,13 @@ func main() {
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer cancel()
 
+	// After the first signal, restore default handling so that a second Ctrl-C
+	// exits immediately instead of waiting for hook actions
+	go func() {
+		<-ctx.Done()
+		cancel()
+	}()
+
 	app := cli.NewCommand()
 	if err := app.Run(ctx, os.Args); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
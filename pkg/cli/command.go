@@ -30,6 +30,9 @@ Use -c/--commit to specify a different commit SHA.`,
 		Action: RunMonitor,
 		Commands: []*cli.Command{
 			NewConfigCommand(),
+			NewHistoryCommand(),
+			NewStatsCommand(),
+			NewHookCommand(),
 		},
 	}
 }
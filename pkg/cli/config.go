@@ -11,19 +11,37 @@ type Config struct {
 	CommitSHA string
 	Interval  time.Duration
 	Silent    bool
+	Output    OutputFormat
+	Display   DisplayMode
+	NoHistory bool
+	// NoResume ignores saved progress for the commit and starts from scratch
+	NoResume bool
+	// FlakyBadge marks workflows that are flaky according to the local history
+	FlakyBadge bool
+	// ShutdownTimeout bounds how long hook actions may run after an interrupt
+	ShutdownTimeout time.Duration
+	// QueuedTimeout is how long a run may stay queued before run_queued_too_long fires
+	QueuedTimeout time.Duration
+	// StrictHooks makes octap exit with an error when a hook action failed
+	StrictHooks bool
 }
 
 func NewConfig() *Config {
 	return &Config{
-		Interval: 5 * time.Second,
+		Interval:        5 * time.Second,
+		Output:          OutputFormatText,
+		Display:         DisplayModeAuto,
+		ShutdownTimeout: 10 * time.Second,
+		QueuedTimeout:   10 * time.Minute,
 	}
 }
 
 func (c *Config) ToMonitorConfig(repo model.Repository) *model.MonitorConfig {
 	return &model.MonitorConfig{
-		CommitSHA: c.CommitSHA,
-		Interval:  c.Interval,
-		Repo:      repo,
+		CommitSHA:     c.CommitSHA,
+		Interval:      c.Interval,
+		Repo:          repo,
+		QueuedTimeout: c.QueuedTimeout,
 	}
 }
 
@@ -54,5 +72,45 @@ func DefineFlags() []cli.Flag {
 			Name:  "config",
 			Usage: "Path to configuration file",
 		},
+		&cli.StringFlag{
+			Name:  "output",
+			Usage: "Output format (text, json, ndjson)",
+			Value: string(OutputFormatText),
+		},
+		&cli.BoolFlag{
+			Name:  "no-history",
+			Usage: "Do not record this session in the local history",
+			Value: false,
+		},
+		&cli.StringFlag{
+			Name:  "display",
+			Usage: "Text display mode (auto, progress, plain, tui); auto uses plain when stdout is not a terminal",
+			Value: string(DisplayModeAuto),
+		},
+		&cli.BoolFlag{
+			Name:  "no-resume",
+			Usage: "Ignore saved progress for the commit and fire hooks as if monitoring for the first time",
+			Value: false,
+		},
+		&cli.BoolFlag{
+			Name:  "flaky-badge",
+			Usage: "Mark workflows that are known to be flaky from the local history",
+			Value: false,
+		},
+		&cli.DurationFlag{
+			Name:  "shutdown-timeout",
+			Usage: "How long to wait for running hook actions after an interrupt",
+			Value: 10 * time.Second,
+		},
+		&cli.DurationFlag{
+			Name:  "queued-timeout",
+			Usage: "How long a workflow run may stay queued before the run_queued_too_long hook fires (0 disables it)",
+			Value: 10 * time.Minute,
+		},
+		&cli.BoolFlag{
+			Name:  "strict-hooks",
+			Usage: "Exit with an error when a hook action failed",
+			Value: false,
+		},
 	}
 }
@@ -3,8 +3,6 @@ package cli
 import (
 	"context"
 	"fmt"
-	"os"
-	"path/filepath"
 
 	"github.com/m-mizutani/octap/pkg/usecase"
 	"github.com/urfave/cli/v3"
@@ -43,11 +41,10 @@ func configInitAction(ctx context.Context, cmd *cli.Command) error {
 	outputPath := cmd.String("output")
 	if outputPath == "" {
 		// Use default path
-		homeDir, err := os.UserHomeDir()
-		if err != nil {
-			return fmt.Errorf("failed to determine home directory for default config path: %w", err)
+		outputPath = service.GetDefaultPath()
+		if outputPath == "" {
+			return fmt.Errorf("failed to determine home directory for default config path; use --output to specify one")
 		}
-		outputPath = filepath.Join(homeDir, ".config", "octap", "config.yml")
 	}
 
 	force := cmd.Bool("force")
@@ -34,4 +34,43 @@ func TestConfig(t *testing.T) {
 		gt.Equal(t, monitorConfig.Repo.Owner, "owner")
 		gt.Equal(t, monitorConfig.Repo.Name, "repo")
 	})
+
+	t.Run("strict hooks fail on failed actions", func(t *testing.T) {
+		hooks := model.HookResults{
+			{Type: "slack", Status: model.HookStatusFailed},
+			{Type: "sound", Status: model.HookStatusSuccess},
+		}
+		gt.NoError(t, cli.CheckHookResults(&cli.Config{}, hooks))
+		gt.Error(t, cli.CheckHookResults(&cli.Config{StrictHooks: true}, hooks))
+		gt.NoError(t, cli.CheckHookResults(&cli.Config{StrictHooks: true}, hooks[1:]))
+	})
+}
+
+func TestHasHooks(t *testing.T) {
+	gt.False(t, cli.HasHooks(model.HooksConfig{}))
+	gt.True(t, cli.HasHooks(model.HooksConfig{CheckFailure: []model.Action{{Type: "sound"}}}))
+
+	// A project may configure only lifecycle events
+	for _, event := range model.HookEvents {
+		config := &model.Config{}
+		switch event {
+		case model.HookRunStarted:
+			config.Hooks.RunStarted = []model.Action{{Type: "sound"}}
+		case model.HookRunQueuedTooLong:
+			config.Hooks.RunQueuedTooLong = []model.Action{{Type: "sound"}}
+		case model.HookCheckCancelled:
+			config.Hooks.CheckCancelled = []model.Action{{Type: "sound"}}
+		case model.HookCheckTimedOut:
+			config.Hooks.CheckTimedOut = []model.Action{{Type: "sound"}}
+		case model.HookCheckSkipped:
+			config.Hooks.CheckSkipped = []model.Action{{Type: "sound"}}
+		case model.HookFirstFailure:
+			config.Hooks.FirstFailure = []model.Action{{Type: "sound"}}
+		case model.HookRecovered:
+			config.Hooks.Recovered = []model.Action{{Type: "sound"}}
+		default:
+			continue
+		}
+		gt.True(t, cli.HasHooks(config.Hooks))
+	}
 }
@@ -20,6 +20,7 @@ type DisplayManager struct {
 	completedCount int
 	firstDisplay   bool
 	lastCheckTime  time.Time
+	flaky          map[string]bool
 }
 
 func NewDisplayManager(repoName, commitSHA string) interfaces.ExtendedDisplay {
@@ -32,8 +33,17 @@ func NewDisplayManager(repoName, commitSHA string) interfaces.ExtendedDisplay {
 	}
 }
 
+// SetFlakyWorkflows marks the named workflows with a "known flaky" badge
+func (d *DisplayManager) SetFlakyWorkflows(names []string) {
+	d.flaky = make(map[string]bool, len(names))
+	for _, name := range names {
+		d.flaky[name] = true
+	}
+}
+
+// Clear erases the countdown line
 func (d *DisplayManager) Clear() {
-	// Not needed for this display
+	fmt.Print("\r\033[K")
 }
 
 func (d *DisplayManager) Update(runs []*model.WorkflowRun, lastUpdate time.Time, interval time.Duration) {
@@ -56,7 +66,7 @@ func (d *DisplayManager) Update(runs []*model.WorkflowRun, lastUpdate time.Time,
 		d.totalCount = len(newRuns)
 
 		if len(newRuns) == 0 {
-			fmt.Printf("⏳ Waiting for workflows to start for commit %s...\n", d.commitSHA[:8])
+			fmt.Printf("⏳ Waiting for workflows to start for commit %s...\n", model.ShortSHA(d.commitSHA))
 			return
 		}
 
@@ -115,9 +125,14 @@ func (d *DisplayManager) Update(runs []*model.WorkflowRun, lastUpdate time.Time,
 		fmt.Print("\r\033[K")
 
 		// Show progress and changes
-		timestamp := time.Now().Format("15:04:05")
+		now := time.Now()
+		timestamp := now.Format("15:04:05")
 		progressBar := d.getProgressBar()
-		fmt.Printf("\n%s %s [%s]\n", progressBar, getProgressText(d.completedCount, d.totalCount), timestamp)
+		fmt.Printf("\n%s %s [%s]", progressBar, getProgressText(d.completedCount, d.totalCount), timestamp)
+		if eta := formatETA(d.estimateCompletion(now), now); eta != "" {
+			fmt.Printf(" %s", eta)
+		}
+		fmt.Println()
 
 		for _, run := range changedRuns {
 			fmt.Printf("  └─ ")
@@ -132,44 +147,57 @@ func (d *DisplayManager) ShowWaiting(commitSHA, repoName string) {
 
 func (d *DisplayManager) ShowCountdown(remaining time.Duration) {
 	// Show countdown on the same line
-	fmt.Printf("\r\033[K⏱️  Next check in: %s", formatDuration(remaining))
+	fmt.Printf("\r\033[K⏱️  Next check in: %s", model.FormatDuration(remaining))
+
+	now := time.Now()
+	if eta := formatETA(d.estimateCompletion(now), now); eta != "" {
+		fmt.Printf(" | %s", eta)
+	}
 }
 
-func (d *DisplayManager) ShowFinalSummary() {
+func (d *DisplayManager) estimateCompletion(now time.Time) time.Time {
+	runs := make([]*model.WorkflowRun, 0, len(d.currentRuns))
+	for _, run := range d.currentRuns {
+		runs = append(runs, run)
+	}
+	return model.EstimateCompletion(runs, now)
+}
+
+func (d *DisplayManager) ShowFinalSummary(summary *model.Summary, hooks model.HookResults) {
 	fmt.Print("\r\033[K") // Clear countdown line
 
 	fmt.Println("\n" + strings.Repeat("═", 50))
-	fmt.Println("✨ All workflows completed!")
-	fmt.Println(strings.Repeat("═", 50))
-
-	successCount := 0
-	failureCount := 0
-	otherCount := 0
-
-	for _, run := range d.currentRuns {
-		if run.Status == model.WorkflowStatusCompleted {
-			switch run.Conclusion {
-			case model.WorkflowConclusionSuccess:
-				successCount++
-			case model.WorkflowConclusionFailure:
-				failureCount++
-			default:
-				otherCount++
-			}
-		}
+	if summary.PendingCount > 0 {
+		// Monitoring was interrupted before every workflow finished
+		fmt.Printf("⏹️  Monitoring stopped: %s\n", getProgressText(summary.TotalRuns-summary.PendingCount, summary.TotalRuns))
+	} else {
+		fmt.Println("✨ All workflows completed!")
 	}
+	fmt.Println(strings.Repeat("═", 50))
 
 	fmt.Printf("📊 Results: ")
-	if successCount > 0 {
-		_, _ = color.New(color.FgGreen).Printf("✅ %d success ", successCount)
+	if summary.SuccessCount > 0 {
+		_, _ = color.New(color.FgGreen).Printf("✅ %d success ", summary.SuccessCount)
+	}
+	if summary.FailureCount > 0 {
+		_, _ = color.New(color.FgRed).Printf("❌ %d failed ", summary.FailureCount)
 	}
-	if failureCount > 0 {
-		_, _ = color.New(color.FgRed).Printf("❌ %d failed ", failureCount)
+	if summary.OtherCount > 0 {
+		_, _ = color.New(color.FgYellow).Printf("⚠️  %d other ", summary.OtherCount)
 	}
-	if otherCount > 0 {
-		_, _ = color.New(color.FgYellow).Printf("⚠️  %d other", otherCount)
+	if summary.PendingCount > 0 {
+		_, _ = color.New(color.FgCyan).Printf("⏳ %d not finished", summary.PendingCount)
 	}
 	fmt.Println()
+
+	if text := getHookSummaryText(hooks); text != "" {
+		fmt.Printf("🪝 %s\n", text)
+		for _, result := range hooks {
+			if result.Status == model.HookStatusFailed {
+				_, _ = color.New(color.FgRed).Printf("   ❌ %s\n", getHookFailureText(result))
+			}
+		}
+	}
 }
 
 func (d *DisplayManager) printWorkflowLine(run *model.WorkflowRun) {
@@ -196,6 +224,14 @@ func (d *DisplayManager) printWorkflowLine(run *model.WorkflowRun) {
 	fmt.Printf("%s ", icon)
 	_, _ = statusColor.Printf("%-20s %s", run.Name, statusText)
 
+	if timing := formatTiming(run, time.Now()); timing != "" {
+		fmt.Printf(" (%s)", timing)
+	}
+
+	if d.flaky[run.Name] {
+		_, _ = color.New(color.FgYellow).Print(" 🎲 known flaky")
+	}
+
 	// Show URL for failed workflows
 	if run.Status == model.WorkflowStatusCompleted && run.Conclusion == model.WorkflowConclusionFailure {
 		fmt.Printf(" 🔗 %s", run.URL)
@@ -274,10 +310,77 @@ func getProgressText(completed, total int) string {
 	return fmt.Sprintf("%d/%d completed", completed, total)
 }
 
-func formatDuration(d time.Duration) string {
-	seconds := int(d.Seconds())
-	if seconds < 0 {
-		return "0s"
+// getHookSummaryText returns a compact line such as "hooks: 3 ok, 1 failed", or
+// an empty string when no hook action ran
+func getHookSummaryText(hooks model.HookResults) string {
+	if len(hooks) == 0 {
+		return ""
+	}
+
+	parts := []string{fmt.Sprintf("%d ok", hooks.Count(model.HookStatusSuccess))}
+	if failed := hooks.Count(model.HookStatusFailed); failed > 0 {
+		parts = append(parts, fmt.Sprintf("%d failed", failed))
+	}
+	if skipped := hooks.Count(model.HookStatusSkipped); skipped > 0 {
+		parts = append(parts, fmt.Sprintf("%d skipped", skipped))
+	}
+	return "hooks: " + strings.Join(parts, ", ")
+}
+
+// getHookFailureText describes a failed hook action, e.g. "slack on check_failure (build): ..."
+func getHookFailureText(result model.HookResult) string {
+	name := result.Type
+	if result.ID != "" {
+		name = result.ID
+	}
+	text := fmt.Sprintf("%s on %s", name, result.Event)
+	if result.Workflow != "" {
+		text += fmt.Sprintf(" (%s)", result.Workflow)
+	}
+	return text + ": " + result.Error
+}
+
+// formatElapsed formats a duration compactly, e.g. 45s, 3m05s, 1h02m
+func formatElapsed(d time.Duration) string {
+	if d <= 0 {
+		return "-"
+	}
+	d = d.Round(time.Second)
+	if d < time.Minute {
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+	if d < time.Hour {
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	}
+	return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+// formatRemaining formats the estimated time left
+func formatRemaining(d time.Duration) string {
+	if d <= 0 {
+		return "finishing soon"
+	}
+	return fmt.Sprintf("~%s left", formatElapsed(d))
+}
+
+// formatTiming returns the elapsed time of a run and, for running workflows
+// with a historical estimate, the time left. It is empty for runs without timestamps.
+func formatTiming(run *model.WorkflowRun, now time.Time) string {
+	if run.CreatedAt.IsZero() {
+		return ""
+	}
+
+	timing := formatElapsed(run.Elapsed(now))
+	if remaining, ok := run.Remaining(now); ok {
+		timing += ", " + formatRemaining(remaining)
+	}
+	return timing
+}
+
+// formatETA returns the estimated finish time for the whole commit, or empty if unknown
+func formatETA(eta, now time.Time) string {
+	if eta.IsZero() {
+		return ""
 	}
-	return fmt.Sprintf("%ds", seconds)
+	return fmt.Sprintf("ETA %s (%s)", eta.Local().Format("15:04"), formatRemaining(eta.Sub(now)))
 }
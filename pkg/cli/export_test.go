@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"context"
+	"io"
+
+	"github.com/m-mizutani/octap/pkg/domain/model"
+)
+
+// NewTestTUIDisplay creates a TUIDisplay that renders to out without touching the terminal
+func NewTestTUIDisplay(out io.Writer, repo model.Repository, quit context.CancelFunc) *TUIDisplay {
+	return newTUIDisplay(context.Background(), TUIDisplayOptions{
+		Repo: repo,
+		Quit: quit,
+		Out:  out,
+	})
+}
+
+// PressKey simulates a key press
+func (d *TUIDisplay) PressKey(key string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.handleKey(key)
+}
+
+// Lines returns the current dashboard contents
+func (d *TUIDisplay) Lines() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.renderLines()
+}
+
+// CheckHookResults exports checkHookResults for testing
+var CheckHookResults = checkHookResults
+
+// LoadHookConfig exports loadHookConfig for testing
+var LoadHookConfig = loadHookConfig
+
+// WriteHookTestResults exports writeHookTestResults for testing
+var WriteHookTestResults = writeHookTestResults
+
+// HasHooks exports hasHooks for testing
+var HasHooks = hasHooks
+
+// LoadMonitorConfig exports loadMonitorConfig for testing
+var LoadMonitorConfig = loadMonitorConfig
+
+// NewLogOutput exports newLogOutput for testing
+var NewLogOutput = newLogOutput
+
+// Hold exports hold for testing
+func (o *logOutput) Hold() { o.hold() }
+
+// Release exports release for testing
+func (o *logOutput) Release() { o.release() }
@@ -3,10 +3,14 @@ package cli
 import (
 	"context"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
+	"slices"
+	"time"
 
 	"github.com/m-mizutani/ctxlog"
+	"github.com/m-mizutani/goerr/v2"
 	"github.com/m-mizutani/octap/pkg/domain"
 	"github.com/m-mizutani/octap/pkg/domain/interfaces"
 	"github.com/m-mizutani/octap/pkg/domain/model"
@@ -16,10 +20,42 @@ import (
 
 // hasHooks checks if the HooksConfig has any configured hooks
 func hasHooks(hooks model.HooksConfig) bool {
-	return len(hooks.CheckSuccess) > 0 ||
-		len(hooks.CheckFailure) > 0 ||
-		len(hooks.CompleteSuccess) > 0 ||
-		len(hooks.CompleteFailure) > 0
+	return slices.ContainsFunc(model.HookEvents, func(event model.HookEvent) bool {
+		return len(hooks.Actions(event)) > 0
+	})
+}
+
+// newDisplay creates the display implementation for the requested output format and display mode.
+// cancel stops monitoring and is used by the interactive dashboard to quit.
+// logs is held by the dashboard while it takes over the terminal.
+func newDisplay(ctx context.Context, cancel context.CancelFunc, config *Config, githubService interfaces.GitHubService, repo model.Repository, logs *logOutput) (interfaces.ExtendedDisplay, error) {
+	repoName := repo.FullName()
+
+	switch config.Output {
+	case OutputFormatText, "":
+		// Text output, rendered according to the display mode below
+	case OutputFormatJSON, OutputFormatNDJSON:
+		return NewJSONDisplay(os.Stdout, repoName, config.CommitSHA), nil
+	default:
+		return nil, goerr.Wrap(domain.ErrConfiguration, "invalid output format", goerr.V("output", config.Output))
+	}
+
+	switch resolveDisplayMode(config.Display, os.Stdout) {
+	case DisplayModeProgress:
+		return NewDisplayManager(repoName, config.CommitSHA), nil
+	case DisplayModePlain:
+		return NewPlainDisplay(os.Stdout, repoName, config.CommitSHA), nil
+	case DisplayModeTUI:
+		return NewTUIDisplay(ctx, TUIDisplayOptions{
+			GitHub:    githubService,
+			Repo:      repo,
+			CommitSHA: config.CommitSHA,
+			Quit:      cancel,
+			logs:      logs,
+		})
+	default:
+		return nil, goerr.Wrap(domain.ErrConfiguration, "invalid display mode", goerr.V("display", config.Display))
+	}
 }
 
 func RunMonitor(ctx context.Context, cmd *cli.Command) error {
@@ -30,7 +66,8 @@ func RunMonitor(ctx context.Context, cmd *cli.Command) error {
 		logLevel = slog.LevelInfo
 	}
 
-	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
+	logs := newLogOutput(os.Stderr)
+	logger := slog.New(slog.NewTextHandler(logs, &slog.HandlerOptions{
 		Level: logLevel,
 	}))
 
@@ -77,86 +114,65 @@ func RunMonitor(ctx context.Context, cmd *cli.Command) error {
 	}
 
 	config := &Config{
-		CommitSHA: commitSHA,
-		Interval:  cmd.Duration("interval"),
-		Silent:    cmd.Bool("silent"),
+		CommitSHA:       commitSHA,
+		Interval:        cmd.Duration("interval"),
+		Silent:          cmd.Bool("silent"),
+		Output:          OutputFormat(cmd.String("output")),
+		Display:         DisplayMode(cmd.String("display")),
+		NoHistory:       cmd.Bool("no-history"),
+		NoResume:        cmd.Bool("no-resume"),
+		FlakyBadge:      cmd.Bool("flaky-badge"),
+		ShutdownTimeout: cmd.Duration("shutdown-timeout"),
+		QueuedTimeout:   cmd.Duration("queued-timeout"),
+		StrictHooks:     cmd.Bool("strict-hooks"),
 	}
 
-	var notifier interfaces.Notifier
-	if config.Silent {
-		notifier = usecase.NewNoOpNotifier()
-	} else {
-		notifier = usecase.NewSoundNotifier()
+	// A broken config file fails before the display takes over the terminal
+	appConfig, err := loadMonitorConfig(ctx, usecase.NewConfigService(), cmd.String("config"), currentDir)
+	if err != nil {
+		return err
 	}
 
-	// Load configuration
-	configService := usecase.NewConfigService()
-	configPath := cmd.String("config")
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
-	var appConfig *model.Config
-	var configErr error
+	display, err := newDisplay(ctx, cancel, config, githubService, *repo, logs)
+	if err != nil {
+		return err
+	}
+	if closer, ok := display.(io.Closer); ok {
+		defer closer.Close()
+	}
+	if config.FlakyBadge {
+		annotateFlakyWorkflows(ctx, display, *repo)
+	}
 
-	if configPath != "" {
-		// Load from specified path (highest priority)
-		appConfig, configErr = configService.Load(configPath)
-		if configErr != nil {
-			logger.Warn("Failed to load configuration file, using defaults",
-				slog.String("path", configPath),
-				slog.String("error", configErr.Error()),
-			)
-		} else {
-			logger.Info("Loaded configuration file",
-				slog.String("path", configPath),
-			)
-		}
+	var notifier interfaces.Notifier
+	if config.Silent {
+		notifier = usecase.NewNoOpNotifier()
 	} else {
-		// Try to load from current directory first
-		var loadedPath string
-		appConfig, loadedPath, configErr = configService.LoadFromDirectory(currentDir)
-		if configErr == nil && hasHooks(appConfig.Hooks) {
-			// Found and loaded config from current directory
-			logger.Info("Loaded configuration file from current directory",
-				slog.String("path", loadedPath),
-			)
-		} else {
-			// No config found in current directory, try default path
-			defaultPath := configService.GetDefaultPath()
-
-			if defaultPath == "" {
-				logger.Debug("Default configuration path not available (home directory could not be determined)")
-			} else {
-				// Check if default config file exists before attempting to load
-				if _, err := os.Stat(defaultPath); err != nil {
-					if os.IsNotExist(err) {
-						logger.Debug("No configuration file found",
-							slog.String("current_dir", currentDir),
-							slog.String("default_path", defaultPath),
-						)
-					}
-				} else {
-					appConfig, configErr = configService.LoadDefault()
-					if configErr == nil && appConfig != nil {
-						logger.Info("Loaded default configuration file",
-							slog.String("path", defaultPath),
-						)
-					}
-				}
-			}
-		}
+		notifier = usecase.NewSoundNotifier()
 	}
 
-	// Set config if loaded successfully
-	if configErr == nil && appConfig != nil {
+	// Set config if one was found
+	if appConfig != nil {
 		notifier.SetConfig(appConfig)
 	}
 
-	display := NewDisplayManager(repo.FullName(), commitSHA)
+	var history interfaces.HistoryStore
+	if !config.NoHistory {
+		history = usecase.NewHistoryStore("")
+	}
 
 	monitor := usecase.NewMonitorUseCase(usecase.MonitorUseCaseOptions{
 		GitHub:   githubService,
 		Notifier: notifier,
 		Display:  display,
 		Config:   config.ToMonitorConfig(*repo),
+		History:  history,
+		State:    usecase.NewStateStore(""),
+		// Progress is still saved with --no-resume so a later restart can resume
+		IgnoreSavedState: config.NoResume,
 	})
 
 	// Run monitor
@@ -165,8 +181,98 @@ func RunMonitor(ctx context.Context, cmd *cli.Command) error {
 		return err
 	}
 
+	if err == context.Canceled {
+		// Interrupted: give in-flight hook actions a bounded time to finish
+		waitForPendingActions(ctx, monitor.WaitForHooks, config.ShutdownTimeout)
+		return checkHookResults(config, notifier.HookResults())
+	}
+
 	// Wait for all pending hook actions to complete before exiting
 	notifier.WaitForPendingActions()
 
+	return checkHookResults(config, notifier.HookResults())
+}
+
+// loadMonitorConfig loads the configuration file given by path. Without a path,
+// it loads .octap.yml in dir if it has hooks and the default file otherwise. A
+// file that exists but fails to load is an error rather than being skipped, so
+// that a mistake in it does not silently turn off its hooks. It returns nil if
+// no file is found.
+func loadMonitorConfig(ctx context.Context, service interfaces.ConfigService, path, dir string) (*model.Config, error) {
+	logger := ctxlog.From(ctx)
+
+	if path != "" {
+		// Load from specified path (highest priority)
+		config, err := service.Load(path)
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to load configuration file %s: %w", domain.ErrConfiguration, path, err)
+		}
+		logger.Info("Loaded configuration file", slog.String("path", path))
+		return config, nil
+	}
+
+	// Try to load from current directory first
+	config, loadedPath, err := service.LoadFromDirectory(dir)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to load configuration file %s: %w", domain.ErrConfiguration, loadedPath, err)
+	}
+	if hasHooks(config.Hooks) {
+		logger.Info("Loaded configuration file from current directory", slog.String("path", loadedPath))
+		return config, nil
+	}
+	if loadedPath == "" {
+		config = nil
+	}
+
+	// No hooks in current directory, try default path
+	defaultPath := service.GetDefaultPath()
+	if defaultPath == "" {
+		logger.Debug("Default configuration path not available (home directory could not be determined)")
+		return config, nil
+	}
+	// Check if default config file exists before attempting to load
+	if _, err := os.Stat(defaultPath); err != nil {
+		if os.IsNotExist(err) {
+			logger.Debug("No configuration file found",
+				slog.String("current_dir", dir),
+				slog.String("default_path", defaultPath),
+			)
+		}
+		return config, nil
+	}
+
+	defaultConfig, err := service.LoadDefault()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to load configuration file %s: %w", domain.ErrConfiguration, defaultPath, err)
+	}
+	logger.Info("Loaded default configuration file", slog.String("path", defaultPath))
+	return defaultConfig, nil
+}
+
+// checkHookResults fails with --strict-hooks if any hook action failed
+func checkHookResults(config *Config, hooks model.HookResults) error {
+	if !config.StrictHooks {
+		return nil
+	}
+	if failed := hooks.Count(model.HookStatusFailed); failed > 0 {
+		return fmt.Errorf("%d of %d hook actions failed", failed, len(hooks))
+	}
 	return nil
 }
+
+// waitForPendingActions calls wait to wait for hook actions up to timeout and gives up with a warning after that
+func waitForPendingActions(ctx context.Context, wait func(), timeout time.Duration) {
+	done := make(chan struct{})
+	go func() {
+		wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		ctxlog.From(ctx).Warn("gave up waiting for hook actions to finish",
+			slog.Duration("timeout", timeout),
+		)
+	}
+}
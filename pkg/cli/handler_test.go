@@ -0,0 +1,75 @@
+package cli_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/m-mizutani/gt"
+	"github.com/m-mizutani/octap/pkg/cli"
+	"github.com/m-mizutani/octap/pkg/domain"
+	"github.com/m-mizutani/octap/pkg/usecase"
+)
+
+func TestLoadMonitorConfig(t *testing.T) {
+	// The default config file is looked up in the home directory
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	defaultPath := filepath.Join(home, ".config", "octap", "config.yml")
+	gt.NoError(t, os.MkdirAll(filepath.Dir(defaultPath), 0700)).Required()
+	gt.NoError(t, os.WriteFile(defaultPath, []byte(`hooks:
+  complete_success:
+    - type: sound
+      path: /tmp/default.wav
+`), 0600)).Required()
+
+	ctx := context.Background()
+
+	t.Run("loads the project config", func(t *testing.T) {
+		dir := t.TempDir()
+		gt.NoError(t, os.WriteFile(filepath.Join(dir, ".octap.yml"), []byte(`hooks:
+  check_failure:
+    - type: sound
+      path: /tmp/fail.wav
+`), 0600)).Required()
+
+		config, err := cli.LoadMonitorConfig(ctx, usecase.NewConfigService(), "", dir)
+		gt.NoError(t, err).Required()
+		gt.A(t, config.Hooks.CheckFailure).Length(1)
+		gt.A(t, config.Hooks.CompleteSuccess).Length(0)
+	})
+
+	t.Run("falls back to the default config", func(t *testing.T) {
+		config, err := cli.LoadMonitorConfig(ctx, usecase.NewConfigService(), "", t.TempDir())
+		gt.NoError(t, err).Required()
+		gt.A(t, config.Hooks.CompleteSuccess).Length(1)
+	})
+
+	t.Run("fails on an invalid project config", func(t *testing.T) {
+		dir := t.TempDir()
+		gt.NoError(t, os.WriteFile(filepath.Join(dir, ".octap.yml"), []byte(`hooks:
+  check_failure:
+    - type: slak
+      webhook_url: https://hooks.slack.com/services/T/B/X
+`), 0600)).Required()
+
+		config, err := cli.LoadMonitorConfig(ctx, usecase.NewConfigService(), "", dir)
+		gt.Error(t, err)
+		gt.True(t, errors.Is(err, domain.ErrConfiguration))
+		gt.True(t, strings.Contains(err.Error(), filepath.Join(dir, ".octap.yml")))
+		// The default config must not be used in its place
+		gt.Nil(t, config)
+	})
+
+	t.Run("fails on an invalid config given by path", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yml")
+		gt.NoError(t, os.WriteFile(path, []byte("hooks: [\n"), 0600)).Required()
+
+		_, err := cli.LoadMonitorConfig(ctx, usecase.NewConfigService(), path, t.TempDir())
+		gt.True(t, errors.Is(err, domain.ErrConfiguration))
+	})
+}
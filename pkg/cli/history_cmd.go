@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/m-mizutani/octap/pkg/domain/model"
+	"github.com/m-mizutani/octap/pkg/usecase"
+	"github.com/urfave/cli/v3"
+)
+
+// NewHistoryCommand creates a new history command
+func NewHistoryCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "history",
+		Usage: "List past monitoring sessions recorded locally",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "repo",
+				Usage: "Filter by repository (owner/name)",
+			},
+			&cli.StringFlag{
+				Name:    "branch",
+				Aliases: []string{"b"},
+				Usage:   "Filter by branch",
+			},
+			&cli.StringFlag{
+				Name:    "workflow",
+				Aliases: []string{"w"},
+				Usage:   "Show only the named workflow; --conclusion then applies to this workflow",
+			},
+			&cli.StringFlag{
+				Name:  "conclusion",
+				Usage: "Filter by conclusion (e.g. success, failure)",
+			},
+			&cli.DurationFlag{
+				Name:  "since",
+				Usage: "Only show sessions finished within this duration (e.g. 168h)",
+			},
+			&cli.IntFlag{
+				Name:    "limit",
+				Aliases: []string{"n"},
+				Usage:   "Maximum number of sessions to show",
+				Value:   20,
+			},
+		},
+		Action: historyAction,
+	}
+}
+
+func historyAction(ctx context.Context, cmd *cli.Command) error {
+	filter := model.HistoryFilter{
+		Repository: cmd.String("repo"),
+		Branch:     cmd.String("branch"),
+		Workflow:   cmd.String("workflow"),
+		Conclusion: cmd.String("conclusion"),
+		Limit:      int(cmd.Int("limit")),
+	}
+	if since := cmd.Duration("since"); since > 0 {
+		filter.Since = time.Now().Add(-since)
+	}
+
+	store := usecase.NewHistoryStore("")
+	sessions, err := store.List(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to read history: %w", err)
+	}
+
+	switch OutputFormat(cmd.String("output")) {
+	case OutputFormatJSON, OutputFormatNDJSON:
+		return writeHistoryJSON(os.Stdout, OutputFormat(cmd.String("output")), sessions)
+	default:
+		return writeHistoryTable(os.Stdout, sessions, filter.Workflow)
+	}
+}
+
+func writeHistoryJSON(w io.Writer, format OutputFormat, sessions []*model.Session) error {
+	encoder := json.NewEncoder(w)
+	if format == OutputFormatJSON {
+		encoder.SetIndent("", "  ")
+		if sessions == nil {
+			sessions = []*model.Session{}
+		}
+		return encoder.Encode(sessions)
+	}
+
+	for _, session := range sessions {
+		if err := encoder.Encode(session); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeHistoryTable(w io.Writer, sessions []*model.Session, workflow string) error {
+	if len(sessions) == 0 {
+		_, err := fmt.Fprintln(w, "No matching sessions found")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	if workflow != "" {
+		_, _ = fmt.Fprintln(tw, "FINISHED\tREPOSITORY\tBRANCH\tCOMMIT\tWORKFLOW\tRESULT\tDURATION\tURL")
+		for _, session := range sessions {
+			run := session.FindRun(workflow)
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				session.FinishedAt.Local().Format("2006-01-02 15:04"),
+				session.Repository,
+				session.Branch,
+				model.ShortSHA(session.CommitSHA),
+				run.Name,
+				plainStatusText(run),
+				formatElapsed(run.Elapsed(session.FinishedAt)),
+				run.URL,
+			)
+		}
+		return tw.Flush()
+	}
+
+	_, _ = fmt.Fprintln(tw, "FINISHED\tREPOSITORY\tBRANCH\tCOMMIT\tRESULT\tDURATION\tRUNS")
+	for _, session := range sessions {
+		failed := 0
+		for _, run := range session.Runs {
+			if run.Conclusion == model.WorkflowConclusionFailure {
+				failed++
+			}
+		}
+		runs := fmt.Sprintf("%d", len(session.Runs))
+		if failed > 0 {
+			runs += fmt.Sprintf(" (%d failed)", failed)
+		}
+
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			session.FinishedAt.Local().Format("2006-01-02 15:04"),
+			session.Repository,
+			session.Branch,
+			model.ShortSHA(session.CommitSHA),
+			session.Conclusion,
+			formatElapsed(session.Duration()),
+			runs,
+		)
+	}
+	return tw.Flush()
+}
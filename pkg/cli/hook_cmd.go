@@ -0,0 +1,241 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/m-mizutani/ctxlog"
+	"github.com/m-mizutani/goerr/v2"
+	"github.com/m-mizutani/octap/pkg/domain"
+	"github.com/m-mizutani/octap/pkg/domain/interfaces"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+	"github.com/m-mizutani/octap/pkg/usecase"
+	"github.com/urfave/cli/v3"
+)
+
+// sampleRepository is used for synthetic events outside a GitHub repository
+var sampleRepository = model.Repository{Owner: "octocat", Name: "hello-world"}
+
+// NewHookCommand creates a new hook command
+func NewHookCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "hook",
+		Usage: "Work with the hooks of the configuration file",
+		Commands: []*cli.Command{
+			{
+				Name:      "test",
+				Usage:     "Fire the hooks of an event with a synthetic event",
+				ArgsUsage: "<event>",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "Print rendered commands, requests and messages instead of sending them",
+					},
+					&cli.Int64Flag{
+						Name:  "run-id",
+						Usage: "Take the workflow run and its commit from this GitHub Actions run",
+					},
+					&cli.StringFlag{
+						Name:  "repo",
+						Usage: "Repository (owner/name); defaults to the repository of the current directory",
+					},
+					&cli.StringFlag{
+						Name:    "workflow",
+						Aliases: []string{"w"},
+						Usage:   "Workflow name",
+					},
+					&cli.StringFlag{
+						Name:    "branch",
+						Aliases: []string{"b"},
+						Usage:   "Branch of the commit",
+					},
+					&cli.StringFlag{
+						Name:  "sha",
+						Usage: "Commit SHA",
+					},
+					&cli.StringFlag{
+						Name:  "message",
+						Usage: "Commit message",
+					},
+					&cli.StringFlag{
+						Name:  "author",
+						Usage: "Commit author",
+					},
+					&cli.StringFlag{
+						Name:  "actor",
+						Usage: "GitHub login that triggered the run",
+					},
+					&cli.StringFlag{
+						Name:  "conclusion",
+						Usage: "Conclusion of the run (default: the one that matches the event)",
+					},
+					&cli.DurationFlag{
+						Name:  "duration",
+						Usage: "Duration of the run",
+					},
+				},
+				Action: hookTestAction,
+			},
+		},
+	}
+}
+
+func hookTestAction(ctx context.Context, cmd *cli.Command) error {
+	logLevel := slog.LevelWarn
+	if cmd.Bool("debug") {
+		logLevel = slog.LevelDebug
+	}
+	ctx = ctxlog.With(ctx, slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
+		Level: logLevel,
+	})))
+
+	eventType := model.HookEvent(cmd.Args().First())
+	if !slices.Contains(model.HookEvents, eventType) {
+		return fmt.Errorf("event must be one of: %s", hookEventNames())
+	}
+
+	currentDir, err := os.Getwd()
+	if err != nil {
+		return domain.ErrConfiguration.Wrap(err)
+	}
+
+	config, path, err := loadHookConfig(usecase.NewConfigService(), cmd.String("config"), currentDir)
+	if err != nil {
+		return err
+	}
+	if len(config.Hooks.Actions(eventType)) == 0 {
+		return fmt.Errorf("no hooks configured for %s in %s", eventType, path)
+	}
+
+	githubService := usecase.NewGitHubService(usecase.NewAuthService(cmd.String("github-oauth-client-id")))
+	repo, err := hookTestRepository(ctx, githubService, cmd.String("repo"), currentDir, cmd.Int64("run-id") != 0)
+	if err != nil {
+		return err
+	}
+
+	event, err := usecase.NewSyntheticEvent(ctx, usecase.SyntheticEventOptions{
+		Type:       eventType,
+		Repo:       repo,
+		RunID:      cmd.Int64("run-id"),
+		GitHub:     githubService,
+		Workflow:   cmd.String("workflow"),
+		Branch:     cmd.String("branch"),
+		SHA:        cmd.String("sha"),
+		Message:    cmd.String("message"),
+		Author:     cmd.String("author"),
+		Actor:      cmd.String("actor"),
+		Conclusion: model.WorkflowConclusion(cmd.String("conclusion")),
+		Duration:   cmd.Duration("duration"),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build event: %w", err)
+	}
+
+	if cmd.Bool("dry-run") {
+		ctx = usecase.WithDryRun(ctx, os.Stdout)
+	}
+
+	executor := usecase.NewHookExecutor(config)
+	if err := executor.Execute(ctx, event); err != nil {
+		return fmt.Errorf("failed to execute hooks: %w", err)
+	}
+	executor.WaitForCompletion()
+
+	return writeHookTestResults(os.Stdout, executor.Results())
+}
+
+// loadHookConfig loads the configuration file the monitor would use: the given
+// path, .octap.yml in dir, or the default file
+func loadHookConfig(service interfaces.ConfigService, path, dir string) (*model.Config, string, error) {
+	if path == "" {
+		config, found, err := service.LoadFromDirectory(dir)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to load configuration file: %w", err)
+		}
+		if found != "" {
+			return config, found, nil
+		}
+		path = service.GetDefaultPath()
+		if path == "" {
+			return nil, "", fmt.Errorf("no configuration file found; use --config to specify one")
+		}
+	}
+
+	config, err := service.Load(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to load configuration file %s: %w", path, err)
+	}
+	return config, path, nil
+}
+
+// hookTestRepository returns the repository of the event. A sample repository
+// is used outside a GitHub repository unless the run is taken from GitHub.
+func hookTestRepository(ctx context.Context, githubService interfaces.GitHubService, name, dir string, needsReal bool) (model.Repository, error) {
+	if name != "" {
+		owner, repo, ok := strings.Cut(name, "/")
+		if !ok || owner == "" || repo == "" {
+			return model.Repository{}, goerr.Wrap(domain.ErrConfiguration, "repository must be owner/name", goerr.V("repo", name))
+		}
+		return model.Repository{Owner: owner, Name: repo}, nil
+	}
+
+	repo, err := githubService.GetRepositoryInfo(ctx, dir)
+	if err != nil {
+		if needsReal {
+			return model.Repository{}, fmt.Errorf("failed to get repository info: %w\nUse --repo or run this command in a Git repository with GitHub remote", err)
+		}
+		ctxlog.From(ctx).Debug("using sample repository", slog.String("error", err.Error()))
+		return sampleRepository, nil
+	}
+	return *repo, nil
+}
+
+// writeHookTestResults prints one line per action and fails if any action failed
+func writeHookTestResults(w io.Writer, results model.HookResults) error {
+	// Actions finish in any order; list them as configured
+	sorted := slices.SortedFunc(slices.Values(results), func(a, b model.HookResult) int {
+		return a.Index - b.Index
+	})
+	for _, result := range sorted {
+		name := result.Type
+		if result.ID != "" {
+			name += " (" + result.ID + ")"
+		}
+		line := fmt.Sprintf("%s [%d] %s %s", hookStatusIcon(result.Status), result.Index, name, result.Duration.Round(time.Millisecond))
+		if result.Error != "" {
+			line += ": " + result.Error
+		}
+		_, _ = fmt.Fprintln(w, line)
+	}
+	_, _ = fmt.Fprintln(w, getHookSummaryText(results))
+
+	if failed := results.Count(model.HookStatusFailed); failed > 0 {
+		return fmt.Errorf("%d of %d hook actions failed", failed, len(results))
+	}
+	return nil
+}
+
+func hookStatusIcon(status model.HookStatus) string {
+	switch status {
+	case model.HookStatusSuccess:
+		return "✅"
+	case model.HookStatusFailed:
+		return "❌"
+	default:
+		return "⏭️"
+	}
+}
+
+func hookEventNames() string {
+	names := make([]string, len(model.HookEvents))
+	for i, event := range model.HookEvents {
+		names[i] = string(event)
+	}
+	return strings.Join(names, ", ")
+}
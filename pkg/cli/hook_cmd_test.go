@@ -0,0 +1,58 @@
+package cli_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/m-mizutani/gt"
+	"github.com/m-mizutani/octap/pkg/cli"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+	"github.com/m-mizutani/octap/pkg/usecase"
+)
+
+func TestHookTest(t *testing.T) {
+	t.Run("loads the configuration of the directory or the given path", func(t *testing.T) {
+		dir := t.TempDir()
+		gt.NoError(t, os.WriteFile(filepath.Join(dir, ".octap.yml"), []byte(`
+hooks:
+  check_failure:
+    - type: sound
+      path: /tmp/fail.wav
+`), 0600)).Required()
+		other := filepath.Join(t.TempDir(), "other.yml")
+		gt.NoError(t, os.WriteFile(other, []byte(`
+hooks:
+  complete_success:
+    - type: sound
+      path: /tmp/done.wav
+`), 0600)).Required()
+
+		config, path, err := cli.LoadHookConfig(usecase.NewConfigService(), "", dir)
+		gt.NoError(t, err).Required()
+		gt.Equal(t, path, filepath.Join(dir, ".octap.yml"))
+		gt.A(t, config.Hooks.CheckFailure).Length(1)
+
+		config, path, err = cli.LoadHookConfig(usecase.NewConfigService(), other, dir)
+		gt.NoError(t, err).Required()
+		gt.Equal(t, path, other)
+		gt.A(t, config.Hooks.CompleteSuccess).Length(1)
+	})
+
+	t.Run("lists results in configured order and fails on failures", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := cli.WriteHookTestResults(&buf, model.HookResults{
+			{Index: 1, Type: "slack", Status: model.HookStatusFailed, Error: "template error"},
+			{Index: 0, ID: "log", Type: "command", Status: model.HookStatusSuccess},
+		})
+		gt.Error(t, err)
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		gt.A(t, lines).Length(3).Required()
+		gt.True(t, strings.HasPrefix(lines[0], "✅ [0] command (log)"))
+		gt.True(t, strings.HasSuffix(lines[1], "slack 0s: template error"))
+		gt.Equal(t, lines[2], "hooks: 1 ok, 1 failed")
+	})
+}
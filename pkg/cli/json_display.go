@@ -0,0 +1,170 @@
+package cli
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/m-mizutani/octap/pkg/domain/interfaces"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+)
+
+// OutputFormat represents the format of monitoring output
+type OutputFormat string
+
+const (
+	OutputFormatText   OutputFormat = "text"
+	OutputFormatJSON   OutputFormat = "json"
+	OutputFormatNDJSON OutputFormat = "ndjson"
+)
+
+// DisplayEventType represents a type of state transition emitted by JSONDisplay
+type DisplayEventType string
+
+const (
+	DisplayEventRunDiscovered DisplayEventType = "run_discovered"
+	DisplayEventStatusChanged DisplayEventType = "status_changed"
+	DisplayEventRunCompleted  DisplayEventType = "run_completed"
+	DisplayEventSummary       DisplayEventType = "summary"
+)
+
+// DisplayEvent is a single structured record written by JSONDisplay
+type DisplayEvent struct {
+	Type       DisplayEventType   `json:"type"`
+	Timestamp  time.Time          `json:"timestamp"`
+	Repository string             `json:"repository"`
+	CommitSHA  string             `json:"commit_sha"`
+	Run        *model.WorkflowRun `json:"run,omitempty"`
+	Previous   *RunState          `json:"previous,omitempty"`
+	Summary    *SummaryRecord     `json:"summary,omitempty"`
+}
+
+// RunState holds the previous state of a run in a status_changed event
+type RunState struct {
+	Status     model.WorkflowStatus     `json:"status"`
+	Conclusion model.WorkflowConclusion `json:"conclusion,omitempty"`
+}
+
+// SummaryRecord holds the result counts emitted in a summary event
+type SummaryRecord struct {
+	TotalRuns    int `json:"total_runs"`
+	SuccessCount int `json:"success_count"`
+	FailureCount int `json:"failure_count"`
+	OtherCount   int `json:"other_count"`
+	// PendingCount is non-zero when monitoring stopped before all runs completed
+	PendingCount    int     `json:"pending_count,omitempty"`
+	DurationSeconds float64 `json:"duration_seconds"`
+	// Hooks are the hook actions run during monitoring
+	Hooks []HookRecord `json:"hooks,omitempty"`
+}
+
+// HookRecord holds the outcome of a hook action in a summary event
+type HookRecord struct {
+	Event           model.HookEvent  `json:"event"`
+	Workflow        string           `json:"workflow,omitempty"`
+	Index           int              `json:"index"`
+	ID              string           `json:"id,omitempty"`
+	Type            string           `json:"type"`
+	Status          model.HookStatus `json:"status"`
+	Error           string           `json:"error,omitempty"`
+	DurationSeconds float64          `json:"duration_seconds"`
+	Retries         int              `json:"retries"`
+}
+
+// JSONDisplay emits one JSON record per state transition instead of decorated text
+type JSONDisplay struct {
+	encoder   *json.Encoder
+	repoName  string
+	commitSHA string
+	runs      map[int64]*model.WorkflowRun
+	now       func() time.Time
+}
+
+// NewJSONDisplay creates a display that writes events to w as line-delimited
+// JSON, one compact object per line. It serves both OutputFormatJSON and
+// OutputFormatNDJSON so that line-oriented consumers work with either.
+func NewJSONDisplay(w io.Writer, repoName, commitSHA string) interfaces.ExtendedDisplay {
+	return &JSONDisplay{
+		encoder:   json.NewEncoder(w),
+		repoName:  repoName,
+		commitSHA: commitSHA,
+		runs:      make(map[int64]*model.WorkflowRun),
+		now:       time.Now,
+	}
+}
+
+func (d *JSONDisplay) Clear() {
+	// Not needed for this display
+}
+
+func (d *JSONDisplay) Update(runs []*model.WorkflowRun, lastUpdate time.Time, interval time.Duration) {
+	for _, run := range runs {
+		previous, exists := d.runs[run.ID]
+		d.runs[run.ID] = run
+
+		if !exists {
+			d.emit(DisplayEvent{Type: DisplayEventRunDiscovered, Run: run})
+			continue
+		}
+
+		if previous.Status == run.Status && previous.Conclusion == run.Conclusion {
+			continue
+		}
+
+		eventType := DisplayEventStatusChanged
+		if run.Status == model.WorkflowStatusCompleted {
+			eventType = DisplayEventRunCompleted
+		}
+		d.emit(DisplayEvent{
+			Type: eventType,
+			Run:  run,
+			Previous: &RunState{
+				Status:     previous.Status,
+				Conclusion: previous.Conclusion,
+			},
+		})
+	}
+}
+
+func (d *JSONDisplay) ShowWaiting(commitSHA, repoName string) {
+	// Not used in this implementation
+}
+
+func (d *JSONDisplay) ShowCountdown(remaining time.Duration) {
+	// Countdown is not a state transition
+}
+
+func (d *JSONDisplay) ShowFinalSummary(summary *model.Summary, hooks model.HookResults) {
+	record := &SummaryRecord{
+		TotalRuns:       summary.TotalRuns,
+		SuccessCount:    summary.SuccessCount,
+		FailureCount:    summary.FailureCount,
+		OtherCount:      summary.OtherCount,
+		PendingCount:    summary.PendingCount,
+		DurationSeconds: summary.Duration.Seconds(),
+	}
+
+	for _, result := range hooks {
+		record.Hooks = append(record.Hooks, HookRecord{
+			Event:           result.Event,
+			Workflow:        result.Workflow,
+			Index:           result.Index,
+			ID:              result.ID,
+			Type:            result.Type,
+			Status:          result.Status,
+			Error:           result.Error,
+			DurationSeconds: result.Duration.Seconds(),
+			Retries:         result.Retries,
+		})
+	}
+
+	d.emit(DisplayEvent{Type: DisplayEventSummary, Summary: record})
+}
+
+func (d *JSONDisplay) emit(event DisplayEvent) {
+	event.Timestamp = d.now()
+	event.Repository = d.repoName
+	event.CommitSHA = d.commitSHA
+	// Encoding errors can only come from the writer; there is nowhere better to report them
+	_ = d.encoder.Encode(event)
+}
@@ -0,0 +1,101 @@
+package cli_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/m-mizutani/gt"
+	"github.com/m-mizutani/octap/pkg/cli"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+)
+
+func decodeEvents(t *testing.T, buf *bytes.Buffer) []cli.DisplayEvent {
+	t.Helper()
+	var events []cli.DisplayEvent
+	decoder := json.NewDecoder(buf)
+	for decoder.More() {
+		var event cli.DisplayEvent
+		gt.NoError(t, decoder.Decode(&event))
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestJSONDisplay(t *testing.T) {
+	t.Run("emits one event per state transition", func(t *testing.T) {
+		var buf bytes.Buffer
+		display := cli.NewJSONDisplay(&buf, "owner/repo", "abc1234567")
+
+		run := &model.WorkflowRun{ID: 1, Name: "build", Status: model.WorkflowStatusQueued}
+		display.Update([]*model.WorkflowRun{run}, time.Now(), time.Second)
+
+		// No change, no event
+		display.Update([]*model.WorkflowRun{run}, time.Now(), time.Second)
+
+		running := &model.WorkflowRun{ID: 1, Name: "build", Status: model.WorkflowStatusInProgress}
+		display.Update([]*model.WorkflowRun{running}, time.Now(), time.Second)
+
+		done := &model.WorkflowRun{
+			ID:         1,
+			Name:       "build",
+			Status:     model.WorkflowStatusCompleted,
+			Conclusion: model.WorkflowConclusionFailure,
+		}
+		display.Update([]*model.WorkflowRun{done}, time.Now(), time.Second)
+		display.ShowCountdown(time.Second)
+		display.ShowFinalSummary(&model.Summary{TotalRuns: 1, FailureCount: 1, Duration: 90 * time.Second}, nil)
+
+		gt.Equal(t, strings.Count(buf.String(), "\n"), 4)
+
+		events := decodeEvents(t, &buf)
+		gt.A(t, events).Length(4)
+		gt.Equal(t, events[0].Type, cli.DisplayEventRunDiscovered)
+		gt.Equal(t, events[0].Repository, "owner/repo")
+		gt.Equal(t, events[0].CommitSHA, "abc1234567")
+		gt.Equal(t, events[1].Type, cli.DisplayEventStatusChanged)
+		gt.Equal(t, events[1].Previous.Status, model.WorkflowStatusQueued)
+		gt.Equal(t, events[2].Type, cli.DisplayEventRunCompleted)
+		gt.Equal(t, events[2].Run.Conclusion, model.WorkflowConclusionFailure)
+		gt.Equal(t, events[3].Type, cli.DisplayEventSummary)
+		gt.Equal(t, events[3].Summary.TotalRuns, 1)
+		gt.Equal(t, events[3].Summary.FailureCount, 1)
+		gt.Equal(t, events[3].Summary.DurationSeconds, 90.0)
+	})
+
+	t.Run("writes one object per line", func(t *testing.T) {
+		var buf bytes.Buffer
+		display := cli.NewJSONDisplay(&buf, "owner/repo", "abc1234567")
+		run := &model.WorkflowRun{ID: 1, Name: "build", Status: model.WorkflowStatusQueued}
+		display.Update([]*model.WorkflowRun{run}, time.Now(), time.Second)
+		display.ShowFinalSummary(&model.Summary{TotalRuns: 1, PendingCount: 1}, nil)
+
+		lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+		gt.A(t, lines).Length(2)
+		for _, line := range lines {
+			var event cli.DisplayEvent
+			gt.NoError(t, json.Unmarshal([]byte(line), &event))
+		}
+	})
+
+	t.Run("summary includes hook results", func(t *testing.T) {
+		var buf bytes.Buffer
+		display := cli.NewJSONDisplay(&buf, "owner/repo", "abc1234567")
+		display.ShowFinalSummary(&model.Summary{}, model.HookResults{
+			{Event: model.HookCheckFailure, Workflow: "build", Type: "slack", Status: model.HookStatusFailed, Error: "server returned status 500", Duration: 1500 * time.Millisecond, Retries: 2},
+			{Event: model.HookCompleteFailure, ID: "page", Type: "command", Status: model.HookStatusSuccess},
+		})
+
+		events := decodeEvents(t, &buf)
+		gt.A(t, events).Length(1).Required()
+		hooks := events[0].Summary.Hooks
+		gt.A(t, hooks).Length(2).Required()
+		gt.Equal(t, hooks[0].Status, model.HookStatusFailed)
+		gt.Equal(t, hooks[0].Workflow, "build")
+		gt.Equal(t, hooks[0].DurationSeconds, 1.5)
+		gt.Equal(t, hooks[0].Retries, 2)
+		gt.Equal(t, hooks[1].ID, "page")
+	})
+}
@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// logOutput is the writer the logger writes to. While held, records are kept
+// in memory instead of being written, so that warnings from hooks or the API
+// don't garble a full-screen display, and they are written out on release.
+type logOutput struct {
+	mu   sync.Mutex
+	w    io.Writer
+	held bool
+	buf  bytes.Buffer
+}
+
+func newLogOutput(w io.Writer) *logOutput {
+	return &logOutput{w: w}
+}
+
+func (o *logOutput) Write(p []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.held {
+		return o.buf.Write(p)
+	}
+	return o.w.Write(p)
+}
+
+// hold keeps the records written from now on in memory until release is called
+func (o *logOutput) hold() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.held = true
+}
+
+// release writes out the records kept since hold and resumes writing directly
+func (o *logOutput) release() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if !o.held {
+		return
+	}
+	o.held = false
+	// There is nowhere better to report a failure to write the log
+	_, _ = o.buf.WriteTo(o.w)
+}
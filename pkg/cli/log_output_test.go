@@ -0,0 +1,37 @@
+package cli_test
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/m-mizutani/gt"
+	"github.com/m-mizutani/octap/pkg/cli"
+)
+
+func TestLogOutput(t *testing.T) {
+	var buf bytes.Buffer
+	logs := cli.NewLogOutput(&buf)
+	logger := slog.New(slog.NewTextHandler(logs, nil))
+
+	logger.Warn("before")
+	gt.True(t, strings.Contains(buf.String(), "msg=before"))
+
+	// Held records are not written until release
+	logs.Hold()
+	logger.Warn("hook failed")
+	gt.False(t, strings.Contains(buf.String(), "hook failed"))
+
+	logs.Release()
+	gt.True(t, strings.Contains(buf.String(), `msg="hook failed"`))
+
+	logger.Warn("after")
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	gt.A(t, lines).Length(3)
+	gt.True(t, strings.Contains(lines[2], "msg=after"))
+
+	// Releasing again writes nothing twice
+	logs.Release()
+	gt.Equal(t, strings.Count(buf.String(), "hook failed"), 1)
+}
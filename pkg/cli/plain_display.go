@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/m-mizutani/octap/pkg/domain/interfaces"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+	"github.com/mattn/go-isatty"
+)
+
+// DisplayMode selects how text output is rendered
+type DisplayMode string
+
+const (
+	DisplayModeAuto     DisplayMode = "auto"
+	DisplayModeProgress DisplayMode = "progress"
+	DisplayModePlain    DisplayMode = "plain"
+	DisplayModeTUI      DisplayMode = "tui"
+)
+
+// resolveDisplayMode turns DisplayModeAuto into a concrete mode based on the terminal.
+// The progress display redraws its countdown line, which only makes sense on an
+// interactive terminal that understands ANSI escapes.
+func resolveDisplayMode(mode DisplayMode, stdout *os.File) DisplayMode {
+	if mode != DisplayModeAuto && mode != "" {
+		return mode
+	}
+
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return DisplayModePlain
+	}
+	if !isatty.IsTerminal(stdout.Fd()) && !isatty.IsCygwinTerminal(stdout.Fd()) {
+		return DisplayModePlain
+	}
+	return DisplayModeProgress
+}
+
+// PlainDisplay prints one timestamped line per state transition without
+// colors, emoji or cursor control, suitable for CI logs and piping to files
+type PlainDisplay struct {
+	w         io.Writer
+	repoName  string
+	commitSHA string
+	runs      map[int64]*model.WorkflowRun
+	waiting   bool
+	now       func() time.Time
+}
+
+// NewPlainDisplay creates a line-oriented display writing to w
+func NewPlainDisplay(w io.Writer, repoName, commitSHA string) interfaces.ExtendedDisplay {
+	return &PlainDisplay{
+		w:         w,
+		repoName:  repoName,
+		commitSHA: commitSHA,
+		runs:      make(map[int64]*model.WorkflowRun),
+		now:       time.Now,
+	}
+}
+
+func (d *PlainDisplay) Clear() {
+	// Not needed for this display
+}
+
+func (d *PlainDisplay) Update(runs []*model.WorkflowRun, lastUpdate time.Time, interval time.Duration) {
+	if len(runs) == 0 && len(d.runs) == 0 {
+		if !d.waiting {
+			d.waiting = true
+			d.printf("waiting for workflows to start for %s@%s", d.repoName, model.ShortSHA(d.commitSHA))
+		}
+		return
+	}
+
+	for _, run := range runs {
+		previous, exists := d.runs[run.ID]
+		d.runs[run.ID] = run
+
+		switch {
+		case !exists:
+			d.printf("%s: %s%s%s", run.Name, plainStatusText(run), d.timingSuffix(run), plainURLSuffix(run))
+		case previous.Status != run.Status || previous.Conclusion != run.Conclusion:
+			d.printf("%s: %s -> %s%s%s", run.Name, plainStatusText(previous), plainStatusText(run), d.timingSuffix(run), plainURLSuffix(run))
+		}
+	}
+}
+
+func (d *PlainDisplay) ShowWaiting(commitSHA, repoName string) {
+	// Waiting is reported from Update
+}
+
+func (d *PlainDisplay) ShowCountdown(remaining time.Duration) {
+	// Countdown is intentionally not printed to keep logs quiet
+}
+
+func (d *PlainDisplay) ShowFinalSummary(summary *model.Summary, hooks model.HookResults) {
+	if summary.PendingCount > 0 {
+		d.printf("monitoring stopped: %d success, %d failed, %d other, %d not finished", summary.SuccessCount, summary.FailureCount, summary.OtherCount, summary.PendingCount)
+	} else {
+		d.printf("all workflows completed: %d success, %d failed, %d other", summary.SuccessCount, summary.FailureCount, summary.OtherCount)
+	}
+	d.printHookResults(hooks)
+}
+
+func (d *PlainDisplay) printHookResults(hooks model.HookResults) {
+	text := getHookSummaryText(hooks)
+	if text == "" {
+		return
+	}
+	d.printf("%s", text)
+	for _, result := range hooks {
+		if result.Status == model.HookStatusFailed {
+			d.printf("hook failed: %s", getHookFailureText(result))
+		}
+	}
+}
+
+func (d *PlainDisplay) printf(format string, args ...any) {
+	timestamp := d.now().Format(time.RFC3339)
+	_, _ = fmt.Fprintf(d.w, "%s %s\n", timestamp, fmt.Sprintf(format, args...))
+}
+
+func (d *PlainDisplay) timingSuffix(run *model.WorkflowRun) string {
+	if timing := formatTiming(run, d.now()); timing != "" {
+		return " (" + timing + ")"
+	}
+	return ""
+}
+
+func plainStatusText(run *model.WorkflowRun) string {
+	if run.Status == model.WorkflowStatusCompleted {
+		return string(run.Conclusion)
+	}
+	return string(run.Status)
+}
+
+func plainURLSuffix(run *model.WorkflowRun) string {
+	if run.Status == model.WorkflowStatusCompleted && run.Conclusion == model.WorkflowConclusionFailure {
+		return " " + run.URL
+	}
+	return ""
+}
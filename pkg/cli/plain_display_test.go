@@ -0,0 +1,64 @@
+package cli_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/m-mizutani/gt"
+	"github.com/m-mizutani/octap/pkg/cli"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+)
+
+func TestPlainDisplay(t *testing.T) {
+	t.Run("prints only transitions", func(t *testing.T) {
+		var buf bytes.Buffer
+		display := cli.NewPlainDisplay(&buf, "owner/repo", "abc1234567890")
+
+		display.Update(nil, time.Now(), time.Second)
+		display.Update(nil, time.Now(), time.Second)
+
+		queued := &model.WorkflowRun{ID: 1, Name: "build", Status: model.WorkflowStatusQueued}
+		display.Update([]*model.WorkflowRun{queued}, time.Now(), time.Second)
+		display.Update([]*model.WorkflowRun{queued}, time.Now(), time.Second)
+		display.ShowCountdown(3 * time.Second)
+
+		failed := &model.WorkflowRun{
+			ID:         1,
+			Name:       "build",
+			Status:     model.WorkflowStatusCompleted,
+			Conclusion: model.WorkflowConclusionFailure,
+			URL:        "https://github.com/owner/repo/actions/runs/1",
+		}
+		display.Update([]*model.WorkflowRun{failed}, time.Now(), time.Second)
+		display.ShowFinalSummary(&model.Summary{TotalRuns: 1, FailureCount: 1}, nil)
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		gt.A(t, lines).Length(4)
+		gt.True(t, strings.HasSuffix(lines[0], "waiting for workflows to start for owner/repo@abc1234"))
+		gt.True(t, strings.HasSuffix(lines[1], "build: queued"))
+		gt.True(t, strings.HasSuffix(lines[2], "build: queued -> failure https://github.com/owner/repo/actions/runs/1"))
+		gt.True(t, strings.HasSuffix(lines[3], "all workflows completed: 0 success, 1 failed, 0 other"))
+
+		// No cursor control sequences in plain output
+		gt.False(t, strings.Contains(buf.String(), "\033"))
+		gt.False(t, strings.Contains(buf.String(), "\r"))
+	})
+
+	t.Run("prints hook results after the summary", func(t *testing.T) {
+		var buf bytes.Buffer
+		display := cli.NewPlainDisplay(&buf, "owner/repo", "abc1234567890")
+		display.ShowFinalSummary(&model.Summary{}, model.HookResults{
+			{Event: model.HookCheckFailure, Workflow: "build", Type: "slack", Status: model.HookStatusFailed, Error: "server returned status 500"},
+			{Event: model.HookCompleteFailure, Type: "sound", Status: model.HookStatusSuccess},
+			{Event: model.HookCompleteFailure, Type: "command", Status: model.HookStatusSuccess},
+			{Event: model.HookCompleteFailure, Type: "webhook", Status: model.HookStatusSkipped},
+		})
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		gt.A(t, lines).Length(3)
+		gt.True(t, strings.HasSuffix(lines[1], "hooks: 2 ok, 1 failed, 1 skipped"))
+		gt.True(t, strings.HasSuffix(lines[2], "hook failed: slack on check_failure (build): server returned status 500"))
+	})
+}
@@ -0,0 +1,166 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"text/tabwriter"
+
+	"github.com/m-mizutani/ctxlog"
+	"github.com/m-mizutani/octap/pkg/domain"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+	"github.com/m-mizutani/octap/pkg/usecase"
+	"github.com/urfave/cli/v3"
+)
+
+// flakyAnnotator is implemented by displays that can mark known flaky workflows
+type flakyAnnotator interface {
+	SetFlakyWorkflows(names []string)
+}
+
+// NewStatsCommand creates a new stats command
+func NewStatsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "stats",
+		Usage: "Report success rate, duration and flakiness of workflows in the current repository",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "source",
+				Usage: "Where to read runs from (api, history)",
+				Value: string(model.StatsSourceAPI),
+			},
+			&cli.StringFlag{
+				Name:    "branch",
+				Aliases: []string{"b"},
+				Usage:   "Only include runs on this branch",
+			},
+			&cli.IntFlag{
+				Name:    "limit",
+				Aliases: []string{"n"},
+				Usage:   "Number of recent runs (api) or sessions (history) to examine",
+				Value:   100,
+			},
+			&cli.FloatFlag{
+				Name:  "flaky-threshold",
+				Usage: "Flip-flop rate at which a workflow is reported as flaky",
+				Value: usecase.DefaultFlakyThreshold,
+			},
+		},
+		Action: statsAction,
+	}
+}
+
+func statsAction(ctx context.Context, cmd *cli.Command) error {
+	logLevel := slog.LevelWarn
+	if cmd.Bool("debug") {
+		logLevel = slog.LevelDebug
+	}
+	ctx = ctxlog.With(ctx, slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
+		Level: logLevel,
+	})))
+
+	githubService := usecase.NewGitHubService(usecase.NewAuthService(cmd.String("github-oauth-client-id")))
+
+	currentDir, err := os.Getwd()
+	if err != nil {
+		return domain.ErrConfiguration.Wrap(err)
+	}
+	repo, err := githubService.GetRepositoryInfo(ctx, currentDir)
+	if err != nil {
+		return fmt.Errorf("failed to get repository info: %w\nPlease run this command in a Git repository with GitHub remote", err)
+	}
+
+	stats := usecase.NewStatsUseCase(usecase.StatsUseCaseOptions{
+		GitHub:  githubService,
+		History: usecase.NewHistoryStore(""),
+		Repo:    *repo,
+	})
+	result, err := stats.Collect(ctx, usecase.StatsQuery{
+		Source:         model.StatsSource(cmd.String("source")),
+		Branch:         cmd.String("branch"),
+		Limit:          int(cmd.Int("limit")),
+		FlakyThreshold: cmd.Float("flaky-threshold"),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to collect workflow statistics: %w", err)
+	}
+
+	switch OutputFormat(cmd.String("output")) {
+	case OutputFormatJSON, OutputFormatNDJSON:
+		return writeStatsJSON(os.Stdout, OutputFormat(cmd.String("output")), result)
+	default:
+		return writeStatsTable(os.Stdout, result)
+	}
+}
+
+func writeStatsJSON(w io.Writer, format OutputFormat, stats []*model.WorkflowStats) error {
+	encoder := json.NewEncoder(w)
+	if format == OutputFormatJSON {
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(stats)
+	}
+
+	for _, s := range stats {
+		if err := encoder.Encode(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeStatsTable(w io.Writer, stats []*model.WorkflowStats) error {
+	if len(stats) == 0 {
+		_, err := fmt.Fprintln(w, "No completed workflow runs found")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "WORKFLOW\tRUNS\tSUCCESS\tP50\tP95\tQUEUE\tFLIP-FLOP\tFLAKY")
+	for _, s := range stats {
+		flaky := ""
+		if s.Flaky {
+			flaky = "yes"
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%d\t%.0f%%\t%s\t%s\t%s\t%d/%d (%.0f%%)\t%s\n",
+			s.Workflow,
+			s.TotalRuns,
+			s.SuccessRate*100,
+			formatElapsed(s.P50Duration),
+			formatElapsed(s.P95Duration),
+			formatElapsed(s.P50QueueTime),
+			s.FlipFlops, s.Commits, s.FlipFlopRate*100,
+			flaky,
+		)
+	}
+	return tw.Flush()
+}
+
+// annotateFlakyWorkflows marks workflows flagged flaky by the local history on
+// displays that support it. It is best effort and never blocks monitoring.
+func annotateFlakyWorkflows(ctx context.Context, display any, repo model.Repository) {
+	annotator, ok := display.(flakyAnnotator)
+	if !ok {
+		return
+	}
+
+	stats := usecase.NewStatsUseCase(usecase.StatsUseCaseOptions{
+		History: usecase.NewHistoryStore(""),
+		Repo:    repo,
+	})
+	result, err := stats.Collect(ctx, usecase.StatsQuery{Source: model.StatsSourceHistory})
+	if err != nil {
+		ctxlog.From(ctx).Warn("failed to read history for flaky workflows", slog.String("error", err.Error()))
+		return
+	}
+
+	var names []string
+	for _, s := range result {
+		if s.Flaky {
+			names = append(names, s.Workflow)
+		}
+	}
+	annotator.SetFlakyWorkflows(names)
+}
@@ -0,0 +1,684 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/m-mizutani/goerr/v2"
+	"github.com/m-mizutani/octap/pkg/domain"
+	"github.com/m-mizutani/octap/pkg/domain/interfaces"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+	"golang.org/x/term"
+)
+
+// tuiSortKey selects the column used to order runs in the dashboard
+type tuiSortKey int
+
+const (
+	tuiSortByName tuiSortKey = iota
+	tuiSortByStatus
+	tuiSortByDuration
+	tuiSortByStarted
+	tuiSortKeyCount
+)
+
+func (k tuiSortKey) String() string {
+	switch k {
+	case tuiSortByStatus:
+		return "status"
+	case tuiSortByDuration:
+		return "duration"
+	case tuiSortByStarted:
+		return "started"
+	default:
+		return "name"
+	}
+}
+
+// tuiFilter selects which runs are listed in the dashboard
+type tuiFilter int
+
+const (
+	tuiFilterAll tuiFilter = iota
+	tuiFilterActive
+	tuiFilterFailed
+	tuiFilterCount
+)
+
+func (f tuiFilter) String() string {
+	switch f {
+	case tuiFilterActive:
+		return "active"
+	case tuiFilterFailed:
+		return "failed"
+	default:
+		return "all"
+	}
+}
+
+func (f tuiFilter) match(run *model.WorkflowRun) bool {
+	switch f {
+	case tuiFilterActive:
+		return run.Status != model.WorkflowStatusCompleted
+	case tuiFilterFailed:
+		return run.Status == model.WorkflowStatusCompleted && run.Conclusion != model.WorkflowConclusionSuccess
+	default:
+		return true
+	}
+}
+
+// TUIDisplayOptions holds dependencies of TUIDisplay
+type TUIDisplayOptions struct {
+	GitHub    interfaces.GitHubService
+	Repo      model.Repository
+	CommitSHA string
+	// Quit is called when the user asks to stop monitoring
+	Quit context.CancelFunc
+	In   *os.File
+	Out  io.Writer
+	// logs is held while the dashboard is shown and released when it closes
+	logs *logOutput
+}
+
+// TUIDisplay renders a full-screen dashboard of workflow runs with keyboard navigation
+type TUIDisplay struct {
+	mu sync.Mutex
+
+	ctx    context.Context
+	github interfaces.GitHubService
+	repo   model.Repository
+	sha    string
+	quit   context.CancelFunc
+	in     *os.File
+	out    io.Writer
+	logs   *logOutput
+
+	runs     map[int64]*model.WorkflowRun
+	jobs     map[int64][]*model.WorkflowJob
+	expanded map[int64]bool
+	selected int64
+	sortKey  tuiSortKey
+	filter   tuiFilter
+	message  string
+
+	remaining   time.Duration
+	width       int
+	height      int
+	termState   *term.State
+	closed      bool
+	interactive bool
+	now         func() time.Time
+}
+
+// NewTUIDisplay switches the terminal to the alternate screen in raw mode and
+// starts reading key presses. Close must be called to restore the terminal.
+func NewTUIDisplay(ctx context.Context, opts TUIDisplayOptions) (*TUIDisplay, error) {
+	if opts.In == nil {
+		opts.In = os.Stdin
+	}
+	if opts.Out == nil {
+		opts.Out = os.Stdout
+	}
+
+	d := newTUIDisplay(ctx, opts)
+
+	fd := int(opts.In.Fd()) // #nosec G115 - file descriptors fit in int
+	if !term.IsTerminal(fd) {
+		return nil, goerr.Wrap(domain.ErrConfiguration, "tui display requires an interactive terminal")
+	}
+
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return nil, goerr.Wrap(err, "failed to switch terminal to raw mode")
+	}
+	d.termState = state
+	d.interactive = true
+	if d.logs != nil {
+		d.logs.hold()
+	}
+
+	// Enter alternate screen and hide cursor
+	_, _ = fmt.Fprint(d.out, "\033[?1049h\033[?25l")
+
+	go d.readKeys()
+
+	d.mu.Lock()
+	d.render()
+	d.mu.Unlock()
+
+	return d, nil
+}
+
+func newTUIDisplay(ctx context.Context, opts TUIDisplayOptions) *TUIDisplay {
+	return &TUIDisplay{
+		ctx:      ctx,
+		github:   opts.GitHub,
+		repo:     opts.Repo,
+		sha:      opts.CommitSHA,
+		quit:     opts.Quit,
+		in:       opts.In,
+		out:      opts.Out,
+		logs:     opts.logs,
+		runs:     make(map[int64]*model.WorkflowRun),
+		jobs:     make(map[int64][]*model.WorkflowJob),
+		expanded: make(map[int64]bool),
+		width:    100,
+		height:   30,
+		now:      time.Now,
+	}
+}
+
+// Close restores the terminal. It is safe to call more than once.
+func (d *TUIDisplay) Close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.closeLocked()
+}
+
+func (d *TUIDisplay) closeLocked() error {
+	if d.closed || !d.interactive {
+		d.closed = true
+		return nil
+	}
+	d.closed = true
+
+	// Show cursor and leave alternate screen
+	_, _ = fmt.Fprint(d.out, "\033[?25h\033[?1049l")
+
+	fd := int(d.in.Fd()) // #nosec G115 - file descriptors fit in int
+	err := term.Restore(fd, d.termState)
+	// Logs written while the dashboard was shown are replayed on the normal screen
+	if d.logs != nil {
+		d.logs.release()
+	}
+	if err != nil {
+		return goerr.Wrap(err, "failed to restore terminal")
+	}
+	return nil
+}
+
+func (d *TUIDisplay) Clear() {
+	// Screen is fully redrawn on each render
+}
+
+func (d *TUIDisplay) Update(runs []*model.WorkflowRun, lastUpdate time.Time, interval time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.remaining = interval - time.Since(lastUpdate)
+
+	for _, run := range runs {
+		previous, exists := d.runs[run.ID]
+		d.runs[run.ID] = run
+
+		changed := !exists || previous.Status != run.Status || previous.Conclusion != run.Conclusion
+		if changed && d.expanded[run.ID] {
+			go d.fetchJobs(run.ID)
+		}
+	}
+
+	if _, ok := d.runs[d.selected]; !ok {
+		if visible := d.visibleRuns(); len(visible) > 0 {
+			d.selected = visible[0].ID
+		}
+	}
+
+	d.render()
+}
+
+func (d *TUIDisplay) ShowWaiting(commitSHA, repoName string) {
+	// Waiting state is shown in the dashboard header
+}
+
+func (d *TUIDisplay) ShowCountdown(remaining time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	// Only redraw when the displayed second changes
+	if int(remaining.Seconds()) == int(d.remaining.Seconds()) {
+		return
+	}
+	d.remaining = remaining
+	d.render()
+}
+
+// ShowFinalSummary leaves the dashboard and prints the final state to the
+// normal screen so that the result stays visible after octap exits
+func (d *TUIDisplay) ShowFinalSummary(summary *model.Summary, hooks model.HookResults) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	_ = d.closeLocked()
+
+	runs := make([]*model.WorkflowRun, 0, len(d.runs))
+	for _, run := range d.runs {
+		runs = append(runs, run)
+	}
+	sortRuns(runs, tuiSortByName, d.now())
+
+	heading := "✨ All workflows completed"
+	if summary.PendingCount > 0 {
+		heading = "⏹️  Monitoring stopped"
+	}
+
+	_, _ = fmt.Fprintf(d.out, "%s for %s@%s\n", heading, d.repo.FullName(), model.ShortSHA(d.sha))
+	for _, run := range runs {
+		_, _ = fmt.Fprintf(d.out, "%s %-30s %-12s %s\n",
+			getWorkflowIcon(run.Status, run.Conclusion),
+			run.Name,
+			plainStatusText(run),
+			formatElapsed(run.Elapsed(d.now())),
+		)
+	}
+
+	if text := getHookSummaryText(hooks); text != "" {
+		_, _ = fmt.Fprintf(d.out, "🪝 %s\n", text)
+		for _, result := range hooks {
+			if result.Status == model.HookStatusFailed {
+				_, _ = fmt.Fprintf(d.out, "   ❌ %s\n", getHookFailureText(result))
+			}
+		}
+	}
+}
+
+func (d *TUIDisplay) readKeys() {
+	reader := bufio.NewReader(d.in)
+	for {
+		b, err := reader.ReadByte()
+		if err != nil {
+			return
+		}
+
+		key := string(b)
+		// Arrow keys arrive as ESC [ A / ESC [ B
+		if b == 0x1b && reader.Buffered() >= 2 {
+			seq := make([]byte, 2)
+			if _, err := io.ReadFull(reader, seq); err == nil && seq[0] == '[' {
+				switch seq[1] {
+				case 'A':
+					key = "up"
+				case 'B':
+					key = "down"
+				}
+			}
+		}
+
+		d.mu.Lock()
+		stop := d.handleKey(key)
+		d.mu.Unlock()
+		if stop {
+			return
+		}
+	}
+}
+
+// handleKey applies a key press to the dashboard state. It returns true when
+// key reading should stop. The caller must hold d.mu.
+func (d *TUIDisplay) handleKey(key string) bool {
+	if d.closed {
+		return true
+	}
+
+	selected := d.runs[d.selected]
+	d.message = ""
+
+	switch key {
+	case "q", "\x03":
+		d.message = "quitting..."
+		d.render()
+		if d.quit != nil {
+			d.quit()
+		}
+		return true
+
+	case "up", "k":
+		d.moveSelection(-1)
+
+	case "down", "j":
+		d.moveSelection(1)
+
+	case "\r", "\n", " ":
+		if selected != nil {
+			d.expanded[selected.ID] = !d.expanded[selected.ID]
+			if d.expanded[selected.ID] {
+				go d.fetchJobs(selected.ID)
+			}
+		}
+
+	case "s":
+		d.sortKey = (d.sortKey + 1) % tuiSortKeyCount
+
+	case "f":
+		d.filter = (d.filter + 1) % tuiFilterCount
+		if visible := d.visibleRuns(); len(visible) > 0 && (selected == nil || !d.filter.match(selected)) {
+			d.selected = visible[0].ID
+		}
+
+	case "o":
+		if selected != nil {
+			if err := openBrowser(selected.URL); err != nil {
+				d.message = "failed to open browser: " + err.Error()
+			} else {
+				d.message = "opened " + selected.URL
+			}
+		}
+
+	case "R":
+		if selected != nil {
+			if selected.Status != model.WorkflowStatusCompleted {
+				d.message = selected.Name + " is still running"
+			} else {
+				d.message = "re-running " + selected.Name + "..."
+				go d.runOperation("re-run", selected)
+			}
+		}
+
+	case "C":
+		if selected != nil {
+			if selected.Status == model.WorkflowStatusCompleted {
+				d.message = selected.Name + " has already completed"
+			} else {
+				d.message = "cancelling " + selected.Name + "..."
+				go d.runOperation("cancel", selected)
+			}
+		}
+	}
+
+	d.render()
+	return false
+}
+
+func (d *TUIDisplay) moveSelection(delta int) {
+	visible := d.visibleRuns()
+	if len(visible) == 0 {
+		return
+	}
+
+	idx := 0
+	for i, run := range visible {
+		if run.ID == d.selected {
+			idx = i
+			break
+		}
+	}
+
+	idx += delta
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= len(visible) {
+		idx = len(visible) - 1
+	}
+	d.selected = visible[idx].ID
+}
+
+func (d *TUIDisplay) fetchJobs(runID int64) {
+	if d.github == nil {
+		return
+	}
+
+	jobs, err := d.github.GetWorkflowJobs(d.ctx, d.repo, runID)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err != nil {
+		d.message = "failed to fetch jobs: " + err.Error()
+	} else {
+		d.jobs[runID] = jobs
+	}
+	d.render()
+}
+
+// runOperation requests a re-run or cancellation of the run through the GitHub API
+func (d *TUIDisplay) runOperation(name string, run *model.WorkflowRun) {
+	var err error
+	switch {
+	case d.github == nil:
+		err = goerr.New("GitHub client is not available")
+	case name == "cancel":
+		err = d.github.CancelWorkflowRun(d.ctx, d.repo, run.ID)
+	default:
+		err = d.github.RerunWorkflowRun(d.ctx, d.repo, run.ID)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err != nil {
+		d.message = fmt.Sprintf("failed to %s %s: %s", name, run.Name, err.Error())
+	} else {
+		d.message = fmt.Sprintf("%s requested for %s", name, run.Name)
+	}
+	d.render()
+}
+
+// visibleRuns returns filtered and sorted runs. The caller must hold d.mu.
+func (d *TUIDisplay) visibleRuns() []*model.WorkflowRun {
+	var runs []*model.WorkflowRun
+	for _, run := range d.runs {
+		if d.filter.match(run) {
+			runs = append(runs, run)
+		}
+	}
+	sortRuns(runs, d.sortKey, d.now())
+	return runs
+}
+
+// render redraws the whole screen. The caller must hold d.mu.
+func (d *TUIDisplay) render() {
+	if d.closed {
+		return
+	}
+
+	if d.interactive {
+		fd := int(d.in.Fd()) // #nosec G115 - file descriptors fit in int
+		if width, height, err := term.GetSize(fd); err == nil {
+			d.width, d.height = width, height
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("\033[H\033[2J")
+	for _, line := range d.renderLines() {
+		b.WriteString(truncate(line, d.width))
+		b.WriteString("\r\n")
+	}
+	_, _ = fmt.Fprint(d.out, b.String())
+}
+
+// renderLines builds the dashboard contents. The caller must hold d.mu.
+func (d *TUIDisplay) renderLines() []string {
+	now := d.now()
+	visible := d.visibleRuns()
+
+	completed := 0
+	for _, run := range d.runs {
+		if run.Status == model.WorkflowStatusCompleted {
+			completed++
+		}
+	}
+
+	rule := strings.Repeat("─", d.width)
+	runs := make([]*model.WorkflowRun, 0, len(d.runs))
+	for _, run := range d.runs {
+		runs = append(runs, run)
+	}
+	status := fmt.Sprintf("octap  %s@%s  %s  next check in %s",
+		d.repo.FullName(), model.ShortSHA(d.sha), getProgressText(completed, len(d.runs)), model.FormatDuration(d.remaining))
+	if eta := formatETA(model.EstimateCompletion(runs, now), now); eta != "" {
+		status += "  " + eta
+	}
+
+	header := []string{
+		status,
+		fmt.Sprintf("sort: %s  filter: %s", d.sortKey, d.filter),
+		rule,
+		fmt.Sprintf("   %-3s%-14s %-40s %s", "", "STATUS", "WORKFLOW", "TIME"),
+	}
+
+	var body []string
+	selectedLine := 0
+	for _, run := range visible {
+		cursor := " "
+		if run.ID == d.selected {
+			cursor = ">"
+			selectedLine = len(body)
+		}
+		body = append(body, fmt.Sprintf(" %s %s %-14s %-40s %s",
+			cursor,
+			getWorkflowIcon(run.Status, run.Conclusion),
+			plainStatusText(run),
+			run.Name,
+			formatTiming(run, now),
+		))
+
+		if d.expanded[run.ID] {
+			jobs, ok := d.jobs[run.ID]
+			if !ok {
+				body = append(body, "       └─ loading jobs...")
+				continue
+			}
+			for i, job := range jobs {
+				branch := "├─"
+				if i == len(jobs)-1 {
+					branch = "└─"
+				}
+				body = append(body, fmt.Sprintf("       %s %s %-49s %s",
+					branch,
+					getWorkflowIcon(job.Status, job.Conclusion),
+					job.Name,
+					formatElapsed(jobDuration(job, now)),
+				))
+			}
+		}
+	}
+	if len(d.runs) == 0 {
+		body = append(body, "   ⏳ waiting for workflows to start...")
+	} else if len(visible) == 0 {
+		body = append(body, "   no workflows match the filter")
+	}
+
+	detail := []string{rule}
+	if selected := d.runs[d.selected]; selected != nil {
+		detail = append(detail,
+			fmt.Sprintf("%s  (run %d)", selected.Name, selected.ID),
+			fmt.Sprintf("status: %s  created: %s  updated: %s",
+				plainStatusText(selected),
+				selected.CreatedAt.Local().Format("15:04:05"),
+				selected.UpdatedAt.Local().Format("15:04:05")),
+			selected.URL,
+		)
+	} else {
+		detail = append(detail, "", "", "")
+	}
+
+	footer := []string{
+		rule,
+		"[↑/↓] select  [enter] jobs  [s] sort  [f] filter  [o] open  [R] re-run  [C] cancel  [q] quit",
+		d.message,
+	}
+
+	// Scroll the table so that the selected run stays visible
+	bodyHeight := d.height - len(header) - len(detail) - len(footer)
+	if bodyHeight < 1 {
+		bodyHeight = 1
+	}
+	start := 0
+	if selectedLine >= bodyHeight {
+		start = selectedLine - bodyHeight + 1
+	}
+	end := start + bodyHeight
+	if end > len(body) {
+		end = len(body)
+	}
+	body = body[start:end]
+	for len(body) < bodyHeight {
+		body = append(body, "")
+	}
+
+	lines := append(header, body...)
+	lines = append(lines, detail...)
+	return append(lines, footer...)
+}
+
+func sortRuns(runs []*model.WorkflowRun, key tuiSortKey, now time.Time) {
+	sort.SliceStable(runs, func(i, j int) bool {
+		a, b := runs[i], runs[j]
+		switch key {
+		case tuiSortByStatus:
+			if ra, rb := statusRank(a), statusRank(b); ra != rb {
+				return ra < rb
+			}
+		case tuiSortByDuration:
+			if da, db := a.Elapsed(now), b.Elapsed(now); da != db {
+				return da > db
+			}
+		case tuiSortByStarted:
+			if !a.CreatedAt.Equal(b.CreatedAt) {
+				return a.CreatedAt.Before(b.CreatedAt)
+			}
+		}
+		return a.Name < b.Name
+	})
+}
+
+// statusRank orders runs so that those needing attention come first
+func statusRank(run *model.WorkflowRun) int {
+	switch {
+	case run.Status == model.WorkflowStatusCompleted && run.Conclusion == model.WorkflowConclusionFailure:
+		return 0
+	case run.Status == model.WorkflowStatusInProgress:
+		return 1
+	case run.Status == model.WorkflowStatusQueued:
+		return 2
+	case run.Status == model.WorkflowStatusCompleted && run.Conclusion == model.WorkflowConclusionSuccess:
+		return 4
+	default:
+		return 3
+	}
+}
+
+func jobDuration(job *model.WorkflowJob, now time.Time) time.Duration {
+	if job.StartedAt.IsZero() {
+		return 0
+	}
+	if !job.CompletedAt.IsZero() {
+		return job.CompletedAt.Sub(job.StartedAt)
+	}
+	return now.Sub(job.StartedAt)
+}
+
+// truncate cuts a line to the terminal width, counting runes
+func truncate(line string, width int) string {
+	runes := []rune(line)
+	if width <= 0 || len(runes) <= width {
+		return line
+	}
+	return string(runes[:width])
+}
+
+// openBrowser opens the URL with the platform's default handler
+func openBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url) // #nosec G204 - url comes from GitHub API
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url) // #nosec G204 - url comes from GitHub API
+	default:
+		cmd = exec.Command("xdg-open", url) // #nosec G204 - url comes from GitHub API
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go func() { _ = cmd.Wait() }()
+	return nil
+}
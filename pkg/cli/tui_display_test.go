@@ -0,0 +1,80 @@
+package cli_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/m-mizutani/gt"
+	"github.com/m-mizutani/octap/pkg/cli"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+)
+
+func findLine(lines []string, substr string) int {
+	for i, line := range lines {
+		if strings.Contains(line, substr) {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestTUIDisplay(t *testing.T) {
+	now := time.Now()
+	runs := []*model.WorkflowRun{
+		{ID: 1, Name: "build", Status: model.WorkflowStatusCompleted, Conclusion: model.WorkflowConclusionSuccess, CreatedAt: now.Add(-3 * time.Minute), UpdatedAt: now.Add(-time.Minute)},
+		{ID: 2, Name: "test", Status: model.WorkflowStatusCompleted, Conclusion: model.WorkflowConclusionFailure, CreatedAt: now.Add(-2 * time.Minute), UpdatedAt: now},
+		{ID: 3, Name: "lint", Status: model.WorkflowStatusInProgress, CreatedAt: now.Add(-time.Minute)},
+	}
+	repo := model.Repository{Owner: "owner", Name: "repo"}
+
+	t.Run("sort and filter runs", func(t *testing.T) {
+		var buf bytes.Buffer
+		display := cli.NewTestTUIDisplay(&buf, repo, nil)
+		display.Update(runs, now, 5*time.Second)
+
+		lines := display.Lines()
+		gt.True(t, strings.Contains(lines[0], "owner/repo"))
+		gt.True(t, strings.Contains(lines[0], "2/3 completed"))
+		// Sorted by name by default
+		gt.True(t, findLine(lines, "build") < findLine(lines, "lint"))
+		gt.True(t, findLine(lines, "lint") < findLine(lines, "test"))
+
+		// Sort by status puts failures first
+		display.PressKey("s")
+		lines = display.Lines()
+		gt.True(t, strings.Contains(lines[1], "sort: status"))
+		gt.True(t, findLine(lines, "test ") < findLine(lines, "lint"))
+		gt.True(t, findLine(lines, "lint") < findLine(lines, "build"))
+
+		// Filter active runs only
+		display.PressKey("f")
+		lines = display.Lines()
+		gt.True(t, strings.Contains(lines[1], "filter: active"))
+		gt.True(t, findLine(lines, "lint") >= 0)
+		gt.Equal(t, findLine(lines, "build"), -1)
+
+		// Output was written to the screen
+		gt.True(t, strings.Contains(buf.String(), "\033[H"))
+	})
+
+	t.Run("selection moves and quit cancels monitoring", func(t *testing.T) {
+		var buf bytes.Buffer
+		quitCalled := false
+		display := cli.NewTestTUIDisplay(&buf, repo, func() { quitCalled = true })
+		display.Update(runs, now, 5*time.Second)
+
+		gt.True(t, strings.HasPrefix(display.Lines()[findLine(display.Lines(), "build")], " >"))
+		display.PressKey("j")
+		lines := display.Lines()
+		gt.True(t, strings.HasPrefix(lines[findLine(lines, "lint")], " >"))
+		gt.True(t, findLine(lines, "(run 3)") >= 0)
+
+		display.PressKey("C")
+		gt.True(t, findLine(display.Lines(), "cancel") >= 0)
+
+		display.PressKey("q")
+		gt.True(t, quitCalled)
+	})
+}
@@ -16,5 +16,7 @@ type Display interface {
 type ExtendedDisplay interface {
 	Display
 	ShowCountdown(remaining time.Duration)
-	ShowFinalSummary()
+	// ShowFinalSummary shows the result of the workflows, as counted by the monitor,
+	// and of the hook actions run for them
+	ShowFinalSummary(summary *model.Summary, hooks model.HookResults)
 }
@@ -10,4 +10,11 @@ type GitHubService interface {
 	GetWorkflowRuns(ctx context.Context, repo model.Repository, commitSHA string) ([]*model.WorkflowRun, error)
 	GetCurrentCommit(ctx context.Context, repoPath string) (string, error)
 	GetRepositoryInfo(ctx context.Context, repoPath string) (*model.Repository, error)
+	GetRecentWorkflowRuns(ctx context.Context, repo model.Repository, workflowID int64, limit int) ([]*model.WorkflowRun, error)
+	ListWorkflowRuns(ctx context.Context, repo model.Repository, filter model.RunFilter) ([]*model.WorkflowRun, error)
+	GetWorkflowRun(ctx context.Context, repo model.Repository, runID int64) (*model.WorkflowRun, error)
+	GetWorkflowRunAttempt(ctx context.Context, repo model.Repository, runID int64, attempt int) (*model.WorkflowRun, error)
+	GetWorkflowJobs(ctx context.Context, repo model.Repository, runID int64) ([]*model.WorkflowJob, error)
+	RerunWorkflowRun(ctx context.Context, repo model.Repository, runID int64) error
+	CancelWorkflowRun(ctx context.Context, repo model.Repository, runID int64) error
 }
@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/m-mizutani/octap/pkg/domain/model"
+)
+
+// HistoryStore persists monitoring sessions locally
+type HistoryStore interface {
+	Save(ctx context.Context, session *model.Session) error
+	// List returns sessions matching the filter, newest first
+	List(ctx context.Context, filter model.HistoryFilter) ([]*model.Session, error)
+}
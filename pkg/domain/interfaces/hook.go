@@ -12,9 +12,18 @@ type HookExecutor interface {
 	// WaitForCompletion waits for all pending actions to complete.
 	// This should be called only when the process is about to exit.
 	WaitForCompletion()
+	// Results returns the outcome of every action finished so far
+	Results() model.HookResults
 }
 
 // ActionExecutor executes a specific action
 type ActionExecutor interface {
 	Execute(ctx context.Context, action model.Action, event model.WorkflowEvent) error
 }
+
+// OutputActionExecutor is implemented by actions that produce outputs, which
+// later actions of the same event read as {{.Outputs.<id>.<key>}}
+type OutputActionExecutor interface {
+	ActionExecutor
+	ExecuteWithOutputs(ctx context.Context, action model.Action, event model.WorkflowEvent) (map[string]string, error)
+}
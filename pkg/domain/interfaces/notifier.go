@@ -7,11 +7,18 @@ import (
 )
 
 type Notifier interface {
-	NotifySuccess(ctx context.Context, workflow *model.WorkflowRun) error
-	NotifyFailure(ctx context.Context, workflow *model.WorkflowRun) error
+	NotifySuccess(ctx context.Context, workflow *model.WorkflowRun, progress model.Progress) error
+	NotifyFailure(ctx context.Context, workflow *model.WorkflowRun, progress model.Progress) error
+	// NotifyRun is called for the other events of a single run, such as run_started
+	// or check_cancelled. Unlike success and failure, they have no default sound.
+	NotifyRun(ctx context.Context, event model.HookEvent, workflow *model.WorkflowRun, progress model.Progress) error
 	NotifyComplete(ctx context.Context, summary *model.Summary) error
+	// NotifyAborted is called when monitoring is interrupted before all workflows complete
+	NotifyAborted(ctx context.Context, summary *model.Summary) error
 	SetConfig(config *model.Config)
 	// WaitForPendingActions waits for all pending hook actions to complete.
 	// This should be called only when the process is about to exit.
 	WaitForPendingActions()
+	// HookResults returns the outcome of the hook actions finished so far
+	HookResults() model.HookResults
 }
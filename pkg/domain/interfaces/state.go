@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/m-mizutani/octap/pkg/domain/model"
+)
+
+// StateStore persists monitoring progress per repository and commit
+type StateStore interface {
+	// Load returns the saved state, or nil if there is none
+	Load(ctx context.Context, repository, commitSHA string) (*model.MonitorState, error)
+	Save(ctx context.Context, state *model.MonitorState) error
+}
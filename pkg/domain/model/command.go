@@ -1,11 +1,12 @@
 package model
 
-import "time"
-
 // CommandAction represents a command execution action
 type CommandAction struct {
-	Command string        `yaml:"command"`
-	Args    []string      `yaml:"args,omitempty"`
-	Timeout time.Duration `yaml:"timeout,omitempty"`
-	Env     []string      `yaml:"env,omitempty"` // Additional environment variables
+	Command string   `yaml:"command"`
+	Args    []string `yaml:"args,omitempty"`
+	Env     []string `yaml:"env,omitempty"` // Additional environment variables
+	// Stdin writes the event as JSON to the command's standard input
+	Stdin bool `yaml:"stdin,omitempty"`
+	// EventFile writes the event as JSON to a temporary file whose path is set in OCTAP_EVENT_FILE
+	EventFile bool `yaml:"event_file,omitempty"`
 }
@@ -2,6 +2,7 @@ package model
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/m-mizutani/goerr/v2"
@@ -11,6 +12,8 @@ type MonitorConfig struct {
 	CommitSHA string
 	Interval  time.Duration
 	Repo      Repository
+	// QueuedTimeout is how long a run may stay queued before run_queued_too_long fires; zero disables it
+	QueuedTimeout time.Duration
 }
 
 // Config represents the application configuration
@@ -20,16 +23,71 @@ type Config struct {
 
 // HooksConfig defines hooks for workflow events
 type HooksConfig struct {
+	// Sequential runs the actions of every event one after another in the listed
+	// order instead of concurrently
+	Sequential bool `yaml:"sequential,omitempty"`
+	// Policy throttles and deduplicates the actions of all events
+	Policy NotificationPolicy `yaml:"policy,omitempty"`
+
 	CheckSuccess    []Action `yaml:"check_success,omitempty"`
 	CheckFailure    []Action `yaml:"check_failure,omitempty"`
 	CompleteSuccess []Action `yaml:"complete_success,omitempty"`
 	CompleteFailure []Action `yaml:"complete_failure,omitempty"`
+	MonitorAborted  []Action `yaml:"monitor_aborted,omitempty"`
+
+	RunStarted       []Action `yaml:"run_started,omitempty"`
+	RunQueuedTooLong []Action `yaml:"run_queued_too_long,omitempty"`
+	CheckCancelled   []Action `yaml:"check_cancelled,omitempty"`
+	CheckTimedOut    []Action `yaml:"check_timed_out,omitempty"`
+	CheckSkipped     []Action `yaml:"check_skipped,omitempty"`
+	FirstFailure     []Action `yaml:"first_failure,omitempty"`
+	Recovered        []Action `yaml:"recovered,omitempty"`
+}
+
+// Actions returns the actions configured for the event
+func (h *HooksConfig) Actions(event HookEvent) []Action {
+	switch event {
+	case HookCheckSuccess:
+		return h.CheckSuccess
+	case HookCheckFailure:
+		return h.CheckFailure
+	case HookCompleteSuccess:
+		return h.CompleteSuccess
+	case HookCompleteFailure:
+		return h.CompleteFailure
+	case HookMonitorAborted:
+		return h.MonitorAborted
+	case HookRunStarted:
+		return h.RunStarted
+	case HookRunQueuedTooLong:
+		return h.RunQueuedTooLong
+	case HookCheckCancelled:
+		return h.CheckCancelled
+	case HookCheckTimedOut:
+		return h.CheckTimedOut
+	case HookCheckSkipped:
+		return h.CheckSkipped
+	case HookFirstFailure:
+		return h.FirstFailure
+	case HookRecovered:
+		return h.Recovered
+	default:
+		return nil
+	}
 }
 
 // Action represents an action to be executed
 type Action struct {
-	Type string                 `yaml:"type"` // "sound", "slack", "command"
-	Data map[string]interface{} `yaml:",inline"`
+	Type string `yaml:"type"` // "sound", "slack", "command", "notify", "webhook", "discord", "teams", "email", "ntfy", "gotify", "pushover"
+	// When is an optional condition expression; the action runs only when it evaluates to true
+	When string `yaml:"when,omitempty"`
+	// ID names the action so that other actions of the event can depend on it and read its outputs
+	ID string `yaml:"id,omitempty"`
+	// Needs lists the IDs of earlier actions of the event that must succeed before this one starts
+	Needs []string `yaml:"needs,omitempty"`
+	// ContinueOnError lets dependent actions run even if this action fails
+	ContinueOnError bool                   `yaml:"continue_on_error,omitempty"`
+	Data            map[string]interface{} `yaml:",inline"`
 }
 
 // ToSoundAction converts Action to SoundAction for type safety
@@ -64,9 +122,16 @@ func (a *Action) ToSlackAction() (*SlackAction, error) {
 		return nil, goerr.New("action is not a slack type")
 	}
 
-	webhookURL, ok := a.Data["webhook_url"].(string)
-	if !ok || webhookURL == "" {
-		return nil, goerr.New("slack action requires 'webhook_url' field")
+	webhookURL, _ := a.Data["webhook_url"].(string)
+	token, _ := a.Data["token"].(string)
+	channel, _ := a.Data["channel"].(string)
+	switch {
+	case webhookURL == "" && token == "":
+		return nil, goerr.New("slack action requires 'webhook_url' or 'token' field")
+	case webhookURL != "" && token != "":
+		return nil, goerr.New("slack action accepts either 'webhook_url' or 'token', not both")
+	case token != "" && channel == "":
+		return nil, goerr.New("slack action with 'token' requires 'channel' field")
 	}
 
 	message, ok := a.Data["message"].(string)
@@ -76,6 +141,8 @@ func (a *Action) ToSlackAction() (*SlackAction, error) {
 
 	slackAction := &SlackAction{
 		WebhookURL: webhookURL,
+		Token:      token,
+		Channel:    channel,
 		Message:    message,
 	}
 
@@ -89,6 +156,13 @@ func (a *Action) ToSlackAction() (*SlackAction, error) {
 	if userName, ok := a.Data["username"].(string); ok {
 		slackAction.UserName = userName
 	}
+	if blocks, ok := a.Data["blocks"]; ok {
+		text, ok := blocks.(string)
+		if !ok {
+			return nil, goerr.New("slack action 'blocks' must be a JSON template string")
+		}
+		slackAction.Blocks = text
+	}
 
 	return slackAction, nil
 }
@@ -101,7 +175,7 @@ func parseStringSlice(value interface{}, fieldName string) ([]string, error) {
 		for i, item := range v {
 			itemStr, ok := item.(string)
 			if !ok {
-				return nil, goerr.New(fmt.Sprintf("command action '%s' must be string array", fieldName))
+				return nil, goerr.New(fmt.Sprintf("action '%s' must be a string array", fieldName))
 			}
 			result[i] = itemStr
 		}
@@ -109,10 +183,94 @@ func parseStringSlice(value interface{}, fieldName string) ([]string, error) {
 	case []string:
 		return v, nil
 	default:
-		return nil, goerr.New(fmt.Sprintf("command action '%s' must be an array", fieldName))
+		return nil, goerr.New(fmt.Sprintf("action '%s' must be an array", fieldName))
 	}
 }
 
+// parseDuration is a helper function to parse duration fields given as strings like "30s"
+func parseDuration(value interface{}, actionType, fieldName string) (time.Duration, error) {
+	switch v := value.(type) {
+	case string:
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return 0, goerr.Wrap(err, fmt.Sprintf("invalid %s format", fieldName))
+		}
+		return d, nil
+	case time.Duration:
+		return v, nil
+	default:
+		return 0, goerr.New(fmt.Sprintf("%s action '%s' must be a duration string", actionType, fieldName))
+	}
+}
+
+// parseRetryPolicy is a helper function to parse a retry mapping with attempts and backoff
+func parseRetryPolicy(value interface{}, actionType string) (RetryPolicy, error) {
+	var policy RetryPolicy
+
+	fields, ok := value.(map[string]interface{})
+	if !ok {
+		return policy, goerr.New(fmt.Sprintf("%s action 'retry' must be a mapping", actionType))
+	}
+
+	if attempts, ok := fields["attempts"]; ok {
+		n, ok := attempts.(int)
+		if !ok || n < 1 {
+			return policy, goerr.New(fmt.Sprintf("%s action 'retry.attempts' must be a positive integer", actionType))
+		}
+		policy.Attempts = n
+	}
+	if backoff, ok := fields["backoff"]; ok {
+		d, err := parseDuration(backoff, actionType, "retry.backoff")
+		if err != nil {
+			return policy, err
+		}
+		policy.Backoff = d
+	}
+
+	return policy, nil
+}
+
+// parsePushPriority reads the optional 'priority' field of push notification actions
+func parsePushPriority(data map[string]interface{}, actionType string) (PushPriority, error) {
+	value, ok := data["priority"]
+	if !ok {
+		return "", nil
+	}
+
+	priority, _ := value.(string)
+	switch PushPriority(priority) {
+	case PushPriorityMin, PushPriorityLow, PushPriorityDefault, PushPriorityHigh, PushPriorityUrgent:
+		return PushPriority(priority), nil
+	default:
+		return "", goerr.New(fmt.Sprintf("%s action 'priority' must be min, low, default, high or urgent", actionType),
+			goerr.V("priority", value))
+	}
+}
+
+// parseMessageFields is a helper function to parse a list of name/value fields
+func parseMessageFields(value interface{}, actionType string) ([]MessageField, error) {
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, goerr.New(fmt.Sprintf("%s action 'fields' must be an array", actionType))
+	}
+
+	fields := make([]MessageField, 0, len(items))
+	for i, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, goerr.New(fmt.Sprintf("%s action fields must be mappings", actionType), goerr.V("index", i))
+		}
+		name, _ := m["name"].(string)
+		value, _ := m["value"].(string)
+		if name == "" || value == "" {
+			return nil, goerr.New(fmt.Sprintf("%s action fields require 'name' and 'value'", actionType), goerr.V("index", i))
+		}
+		inline, _ := m["inline"].(bool)
+		fields = append(fields, MessageField{Name: name, Value: value, Inline: inline})
+	}
+	return fields, nil
+}
+
 // ToCommandAction converts Action to CommandAction for type safety
 func (a *Action) ToCommandAction() (*CommandAction, error) {
 	if a.Type != "command" {
@@ -137,22 +295,6 @@ func (a *Action) ToCommandAction() (*CommandAction, error) {
 		cmdAction.Args = args
 	}
 
-	// Optional timeout field
-	if timeoutValue, ok := a.Data["timeout"]; ok {
-		switch v := timeoutValue.(type) {
-		case string:
-			timeout, err := time.ParseDuration(v)
-			if err != nil {
-				return nil, goerr.Wrap(err, "invalid timeout format")
-			}
-			cmdAction.Timeout = timeout
-		case time.Duration:
-			cmdAction.Timeout = v
-		default:
-			return nil, goerr.New("command action 'timeout' must be a duration string")
-		}
-	}
-
 	// Optional env field
 	if envValue, ok := a.Data["env"]; ok {
 		env, err := parseStringSlice(envValue, "env")
@@ -162,5 +304,431 @@ func (a *Action) ToCommandAction() (*CommandAction, error) {
 		cmdAction.Env = env
 	}
 
+	if stdin, ok := a.Data["stdin"]; ok {
+		b, ok := stdin.(bool)
+		if !ok {
+			return nil, goerr.New("command action 'stdin' must be a boolean")
+		}
+		cmdAction.Stdin = b
+	}
+	if eventFile, ok := a.Data["event_file"]; ok {
+		b, ok := eventFile.(bool)
+		if !ok {
+			return nil, goerr.New("command action 'event_file' must be a boolean")
+		}
+		cmdAction.EventFile = b
+	}
+
 	return cmdAction, nil
 }
+
+// ToNotifyAction converts Action to NotifyAction for type safety
+func (a *Action) ToNotifyAction() (*NotifyAction, error) {
+	if a.Type != "notify" {
+		return nil, goerr.New("action is not a notify type")
+	}
+
+	body, ok := a.Data["body"].(string)
+	if !ok || body == "" {
+		return nil, goerr.New("notify action requires 'body' field")
+	}
+
+	notifyAction := &NotifyAction{
+		Title:   "octap",
+		Body:    body,
+		Urgency: NotifyUrgencyNormal,
+		OpenRun: true,
+	}
+
+	if title, ok := a.Data["title"].(string); ok && title != "" {
+		notifyAction.Title = title
+	}
+	if icon, ok := a.Data["icon"].(string); ok {
+		notifyAction.Icon = icon
+	}
+	if urgency, ok := a.Data["urgency"].(string); ok {
+		switch NotifyUrgency(urgency) {
+		case NotifyUrgencyLow, NotifyUrgencyNormal, NotifyUrgencyCritical:
+			notifyAction.Urgency = NotifyUrgency(urgency)
+		default:
+			return nil, goerr.New("notify action 'urgency' must be low, normal or critical", goerr.V("urgency", urgency))
+		}
+	}
+	if openRun, ok := a.Data["open_run"]; ok {
+		b, ok := openRun.(bool)
+		if !ok {
+			return nil, goerr.New("notify action 'open_run' must be a boolean")
+		}
+		notifyAction.OpenRun = b
+	}
+	if expireValue, ok := a.Data["expire"]; ok {
+		expire, err := parseDuration(expireValue, "notify", "expire")
+		if err != nil {
+			return nil, err
+		}
+		notifyAction.Expire = expire
+	}
+	if waitValue, ok := a.Data["wait"]; ok {
+		wait, err := parseDuration(waitValue, "notify", "wait")
+		if err != nil {
+			return nil, err
+		}
+		notifyAction.Wait = wait
+	}
+
+	return notifyAction, nil
+}
+
+// ToWebhookAction converts Action to WebhookAction for type safety
+func (a *Action) ToWebhookAction() (*WebhookAction, error) {
+	if a.Type != "webhook" {
+		return nil, goerr.New("action is not a webhook type")
+	}
+
+	url, ok := a.Data["url"].(string)
+	if !ok || url == "" {
+		return nil, goerr.New("webhook action requires 'url' field")
+	}
+
+	webhookAction := &WebhookAction{
+		URL:    url,
+		Method: "POST",
+	}
+
+	if method, ok := a.Data["method"].(string); ok && method != "" {
+		webhookAction.Method = strings.ToUpper(method)
+	}
+	if body, ok := a.Data["body"].(string); ok {
+		webhookAction.Body = body
+	}
+	if secret, ok := a.Data["secret"].(string); ok {
+		webhookAction.Secret = secret
+	}
+
+	if headersValue, ok := a.Data["headers"]; ok {
+		headers, ok := headersValue.(map[string]interface{})
+		if !ok {
+			return nil, goerr.New("webhook action 'headers' must be a mapping")
+		}
+		webhookAction.Headers = make(map[string]string, len(headers))
+		for key, value := range headers {
+			str, ok := value.(string)
+			if !ok {
+				return nil, goerr.New("webhook action header values must be strings", goerr.V("header", key))
+			}
+			webhookAction.Headers[key] = str
+		}
+	}
+
+	return webhookAction, nil
+}
+
+// ToDiscordAction converts Action to DiscordAction for type safety
+func (a *Action) ToDiscordAction() (*DiscordAction, error) {
+	if a.Type != "discord" {
+		return nil, goerr.New("action is not a discord type")
+	}
+
+	webhookURL, ok := a.Data["webhook_url"].(string)
+	if !ok || webhookURL == "" {
+		return nil, goerr.New("discord action requires 'webhook_url' field")
+	}
+
+	message, ok := a.Data["message"].(string)
+	if !ok || message == "" {
+		return nil, goerr.New("discord action requires 'message' field")
+	}
+
+	discordAction := &DiscordAction{
+		WebhookURL: webhookURL,
+		Message:    message,
+	}
+
+	// Optional fields
+	if title, ok := a.Data["title"].(string); ok {
+		discordAction.Title = title
+	}
+	if color, ok := a.Data["color"].(string); ok {
+		discordAction.Color = color
+	}
+	if userName, ok := a.Data["username"].(string); ok {
+		discordAction.UserName = userName
+	}
+	if avatarURL, ok := a.Data["avatar_url"].(string); ok {
+		discordAction.AvatarURL = avatarURL
+	}
+	if fieldsValue, ok := a.Data["fields"]; ok {
+		fields, err := parseMessageFields(fieldsValue, "discord")
+		if err != nil {
+			return nil, err
+		}
+		discordAction.Fields = fields
+	}
+
+	return discordAction, nil
+}
+
+// ToTeamsAction converts Action to TeamsAction for type safety
+func (a *Action) ToTeamsAction() (*TeamsAction, error) {
+	if a.Type != "teams" {
+		return nil, goerr.New("action is not a teams type")
+	}
+
+	webhookURL, ok := a.Data["webhook_url"].(string)
+	if !ok || webhookURL == "" {
+		return nil, goerr.New("teams action requires 'webhook_url' field")
+	}
+
+	message, ok := a.Data["message"].(string)
+	if !ok || message == "" {
+		return nil, goerr.New("teams action requires 'message' field")
+	}
+
+	teamsAction := &TeamsAction{
+		WebhookURL: webhookURL,
+		Message:    message,
+	}
+
+	// Optional fields
+	if title, ok := a.Data["title"].(string); ok {
+		teamsAction.Title = title
+	}
+	if color, ok := a.Data["color"].(string); ok {
+		teamsAction.Color = color
+	}
+	if fieldsValue, ok := a.Data["fields"]; ok {
+		fields, err := parseMessageFields(fieldsValue, "teams")
+		if err != nil {
+			return nil, err
+		}
+		teamsAction.Fields = fields
+	}
+
+	return teamsAction, nil
+}
+
+// ToEmailAction converts Action to EmailAction for type safety
+func (a *Action) ToEmailAction() (*EmailAction, error) {
+	if a.Type != "email" {
+		return nil, goerr.New("action is not an email type")
+	}
+
+	host, ok := a.Data["host"].(string)
+	if !ok || host == "" {
+		return nil, goerr.New("email action requires 'host' field")
+	}
+	from, ok := a.Data["from"].(string)
+	if !ok || from == "" {
+		return nil, goerr.New("email action requires 'from' field")
+	}
+	subject, ok := a.Data["subject"].(string)
+	if !ok || subject == "" {
+		return nil, goerr.New("email action requires 'subject' field")
+	}
+
+	emailAction := &EmailAction{
+		Host:     host,
+		Security: EmailSecuritySTARTTLS,
+		From:     from,
+		Subject:  subject,
+	}
+
+	toValue, ok := a.Data["to"]
+	if !ok {
+		return nil, goerr.New("email action requires 'to' field")
+	}
+	to, err := parseStringSlice(toValue, "to")
+	if err != nil {
+		return nil, err
+	}
+	if len(to) == 0 {
+		return nil, goerr.New("email action requires at least one 'to' address")
+	}
+	emailAction.To = to
+
+	if ccValue, ok := a.Data["cc"]; ok {
+		cc, err := parseStringSlice(ccValue, "cc")
+		if err != nil {
+			return nil, err
+		}
+		emailAction.Cc = cc
+	}
+
+	if security, ok := a.Data["security"].(string); ok {
+		switch EmailSecurity(security) {
+		case EmailSecuritySTARTTLS, EmailSecurityTLS, EmailSecurityNone:
+			emailAction.Security = EmailSecurity(security)
+		default:
+			return nil, goerr.New("email action 'security' must be starttls, tls or none", goerr.V("security", security))
+		}
+	}
+
+	if portValue, ok := a.Data["port"]; ok {
+		port, ok := portValue.(int)
+		if !ok || port <= 0 || port > 65535 {
+			return nil, goerr.New("email action 'port' must be a valid port number")
+		}
+		emailAction.Port = port
+	} else {
+		switch emailAction.Security {
+		case EmailSecurityTLS:
+			emailAction.Port = 465
+		case EmailSecurityNone:
+			emailAction.Port = 25
+		default:
+			emailAction.Port = 587
+		}
+	}
+
+	if username, ok := a.Data["username"].(string); ok {
+		emailAction.Username = username
+	}
+	if password, ok := a.Data["password"].(string); ok {
+		emailAction.Password = password
+	}
+	if body, ok := a.Data["body"].(string); ok {
+		emailAction.Body = body
+	}
+	if html, ok := a.Data["html"].(string); ok {
+		emailAction.HTML = html
+	}
+	if emailAction.Body == "" && emailAction.HTML == "" {
+		return nil, goerr.New("email action requires 'body' or 'html' field")
+	}
+
+	return emailAction, nil
+}
+
+// ToNtfyAction converts Action to NtfyAction for type safety
+func (a *Action) ToNtfyAction() (*NtfyAction, error) {
+	if a.Type != "ntfy" {
+		return nil, goerr.New("action is not a ntfy type")
+	}
+
+	topic, ok := a.Data["topic"].(string)
+	if !ok || topic == "" {
+		return nil, goerr.New("ntfy action requires 'topic' field")
+	}
+	message, ok := a.Data["message"].(string)
+	if !ok || message == "" {
+		return nil, goerr.New("ntfy action requires 'message' field")
+	}
+
+	ntfyAction := &NtfyAction{
+		Server:  "https://ntfy.sh",
+		Topic:   topic,
+		Title:   "octap",
+		Message: message,
+	}
+
+	// Optional fields
+	if server, ok := a.Data["server"].(string); ok && server != "" {
+		ntfyAction.Server = server
+	}
+	if token, ok := a.Data["token"].(string); ok {
+		ntfyAction.Token = token
+	}
+	if title, ok := a.Data["title"].(string); ok && title != "" {
+		ntfyAction.Title = title
+	}
+	if tagsValue, ok := a.Data["tags"]; ok {
+		tags, err := parseStringSlice(tagsValue, "tags")
+		if err != nil {
+			return nil, err
+		}
+		ntfyAction.Tags = tags
+	}
+
+	priority, err := parsePushPriority(a.Data, "ntfy")
+	if err != nil {
+		return nil, err
+	}
+	ntfyAction.Priority = priority
+
+	return ntfyAction, nil
+}
+
+// ToGotifyAction converts Action to GotifyAction for type safety
+func (a *Action) ToGotifyAction() (*GotifyAction, error) {
+	if a.Type != "gotify" {
+		return nil, goerr.New("action is not a gotify type")
+	}
+
+	server, ok := a.Data["server"].(string)
+	if !ok || server == "" {
+		return nil, goerr.New("gotify action requires 'server' field")
+	}
+	token, ok := a.Data["token"].(string)
+	if !ok || token == "" {
+		return nil, goerr.New("gotify action requires 'token' field")
+	}
+	message, ok := a.Data["message"].(string)
+	if !ok || message == "" {
+		return nil, goerr.New("gotify action requires 'message' field")
+	}
+
+	gotifyAction := &GotifyAction{
+		Server:  server,
+		Token:   token,
+		Title:   "octap",
+		Message: message,
+	}
+
+	if title, ok := a.Data["title"].(string); ok && title != "" {
+		gotifyAction.Title = title
+	}
+
+	priority, err := parsePushPriority(a.Data, "gotify")
+	if err != nil {
+		return nil, err
+	}
+	gotifyAction.Priority = priority
+
+	return gotifyAction, nil
+}
+
+// ToPushoverAction converts Action to PushoverAction for type safety
+func (a *Action) ToPushoverAction() (*PushoverAction, error) {
+	if a.Type != "pushover" {
+		return nil, goerr.New("action is not a pushover type")
+	}
+
+	token, ok := a.Data["token"].(string)
+	if !ok || token == "" {
+		return nil, goerr.New("pushover action requires 'token' field")
+	}
+	user, ok := a.Data["user"].(string)
+	if !ok || user == "" {
+		return nil, goerr.New("pushover action requires 'user' field")
+	}
+	message, ok := a.Data["message"].(string)
+	if !ok || message == "" {
+		return nil, goerr.New("pushover action requires 'message' field")
+	}
+
+	pushoverAction := &PushoverAction{
+		Token:   token,
+		User:    user,
+		Title:   "octap",
+		Message: message,
+	}
+
+	// Optional fields
+	if title, ok := a.Data["title"].(string); ok && title != "" {
+		pushoverAction.Title = title
+	}
+	if device, ok := a.Data["device"].(string); ok {
+		pushoverAction.Device = device
+	}
+	if sound, ok := a.Data["sound"].(string); ok {
+		pushoverAction.Sound = sound
+	}
+
+	priority, err := parsePushPriority(a.Data, "pushover")
+	if err != nil {
+		return nil, err
+	}
+	pushoverAction.Priority = priority
+
+	return pushoverAction, nil
+}
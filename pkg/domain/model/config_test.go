@@ -2,6 +2,7 @@ package model_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/m-mizutani/gt"
 	"github.com/m-mizutani/octap/pkg/domain/model"
@@ -42,4 +43,253 @@ func TestActionConversions(t *testing.T) {
 		_, err := action.ToSoundAction()
 		gt.Error(t, err)
 	})
+
+	t.Run("ToCommandAction with event JSON options", func(t *testing.T) {
+		action := model.Action{
+			Type: "command",
+			Data: map[string]interface{}{
+				"command":    "./handle.sh",
+				"stdin":      true,
+				"event_file": true,
+			},
+		}
+
+		cmdAction, err := action.ToCommandAction()
+		gt.NoError(t, err).Required()
+		gt.True(t, cmdAction.Stdin)
+		gt.True(t, cmdAction.EventFile)
+	})
+
+	t.Run("ToCommandAction with invalid stdin", func(t *testing.T) {
+		action := model.Action{
+			Type: "command",
+			Data: map[string]interface{}{
+				"command": "./handle.sh",
+				"stdin":   "yes",
+			},
+		}
+
+		_, err := action.ToCommandAction()
+		gt.Error(t, err)
+	})
+
+	t.Run("ToNotifyAction defaults", func(t *testing.T) {
+		action := model.Action{
+			Type: "notify",
+			Data: map[string]interface{}{
+				"body": "{{.Workflow}} finished",
+			},
+		}
+
+		notifyAction, err := action.ToNotifyAction()
+		gt.NoError(t, err).Required()
+		gt.Equal(t, notifyAction.Title, "octap")
+		gt.Equal(t, notifyAction.Urgency, model.NotifyUrgencyNormal)
+		gt.True(t, notifyAction.OpenRun)
+	})
+
+	t.Run("ToNotifyAction with options", func(t *testing.T) {
+		action := model.Action{
+			Type: "notify",
+			Data: map[string]interface{}{
+				"title":    "CI",
+				"body":     "done",
+				"urgency":  "critical",
+				"open_run": false,
+				"wait":     "30s",
+			},
+		}
+
+		notifyAction, err := action.ToNotifyAction()
+		gt.NoError(t, err).Required()
+		gt.Equal(t, notifyAction.Urgency, model.NotifyUrgencyCritical)
+		gt.False(t, notifyAction.OpenRun)
+		gt.Equal(t, notifyAction.Wait, 30*time.Second)
+	})
+
+	t.Run("ToNotifyAction with invalid urgency", func(t *testing.T) {
+		action := model.Action{
+			Type: "notify",
+			Data: map[string]interface{}{
+				"body":    "done",
+				"urgency": "urgent",
+			},
+		}
+
+		_, err := action.ToNotifyAction()
+		gt.Error(t, err)
+	})
+
+	t.Run("ToWebhookAction defaults", func(t *testing.T) {
+		action := model.Action{
+			Type: "webhook",
+			Data: map[string]interface{}{
+				"url": "https://example.com/hook",
+			},
+		}
+
+		webhookAction, err := action.ToWebhookAction()
+		gt.NoError(t, err)
+		gt.Equal(t, webhookAction.Method, "POST")
+	})
+
+	t.Run("RetryPolicy and Timeout", func(t *testing.T) {
+		action := model.Action{
+			Type: "slack",
+			Data: map[string]interface{}{
+				"webhook_url": "https://hooks.slack.com/services/T/B/X",
+				"retry":       map[string]interface{}{"attempts": 5, "backoff": "2s"},
+				"timeout":     "10s",
+			},
+		}
+
+		retry, err := action.RetryPolicy()
+		gt.NoError(t, err)
+		gt.Equal(t, retry, model.RetryPolicy{Attempts: 5, Backoff: 2 * time.Second})
+		timeout, err := action.Timeout()
+		gt.NoError(t, err)
+		gt.Equal(t, timeout, 10*time.Second)
+	})
+
+	t.Run("RetryPolicy and Timeout are optional", func(t *testing.T) {
+		action := model.Action{Type: "command", Data: map[string]interface{}{"command": "true"}}
+
+		retry, err := action.RetryPolicy()
+		gt.NoError(t, err)
+		gt.Equal(t, retry, model.RetryPolicy{})
+		timeout, err := action.Timeout()
+		gt.NoError(t, err)
+		gt.Equal(t, timeout, 0)
+	})
+
+	t.Run("Timeout of notify action is separate from the display time", func(t *testing.T) {
+		action := model.Action{Type: "notify", Data: map[string]interface{}{"body": "CI failed", "timeout": "1m", "expire": "5s"}}
+
+		timeout, err := action.Timeout()
+		gt.NoError(t, err)
+		gt.Equal(t, timeout, time.Minute)
+		notifyAction, err := action.ToNotifyAction()
+		gt.NoError(t, err).Required()
+		gt.Equal(t, notifyAction.Expire, 5*time.Second)
+	})
+
+	t.Run("invalid retry", func(t *testing.T) {
+		for _, retry := range []interface{}{
+			map[string]interface{}{"attempts": 0},
+			map[string]interface{}{"backoff": "soon"},
+			3,
+		} {
+			action := model.Action{
+				Type: "webhook",
+				Data: map[string]interface{}{"url": "https://example.com/hook", "retry": retry},
+			}
+			_, err := action.RetryPolicy()
+			gt.Error(t, err)
+		}
+	})
+
+	t.Run("invalid timeout", func(t *testing.T) {
+		action := model.Action{Type: "email", Data: map[string]interface{}{"timeout": "-1s"}}
+		_, err := action.Timeout()
+		gt.Error(t, err)
+	})
+
+	t.Run("ToEmailAction defaults", func(t *testing.T) {
+		action := model.Action{
+			Type: "email",
+			Data: map[string]interface{}{
+				"host":    "smtp.example.com",
+				"from":    "octap@example.com",
+				"to":      []interface{}{"dev@example.com"},
+				"subject": "{{.Workflow}} finished",
+				"body":    "see {{.URL}}",
+			},
+		}
+
+		emailAction, err := action.ToEmailAction()
+		gt.NoError(t, err)
+		gt.Equal(t, emailAction.Security, model.EmailSecuritySTARTTLS)
+		gt.Equal(t, emailAction.Port, 587)
+		gt.A(t, emailAction.To).Length(1)
+	})
+
+	t.Run("ToEmailAction with implicit TLS", func(t *testing.T) {
+		action := model.Action{
+			Type: "email",
+			Data: map[string]interface{}{
+				"host":     "smtp.example.com",
+				"security": "tls",
+				"from":     "octap@example.com",
+				"to":       []interface{}{"dev@example.com"},
+				"cc":       []interface{}{"lead@example.com"},
+				"subject":  "done",
+				"html":     "<p>done</p>",
+			},
+		}
+
+		emailAction, err := action.ToEmailAction()
+		gt.NoError(t, err)
+		gt.Equal(t, emailAction.Port, 465)
+		gt.A(t, emailAction.Cc).Length(1)
+	})
+
+	t.Run("ToEmailAction without recipients", func(t *testing.T) {
+		action := model.Action{
+			Type: "email",
+			Data: map[string]interface{}{
+				"host":    "smtp.example.com",
+				"from":    "octap@example.com",
+				"to":      []interface{}{},
+				"subject": "done",
+				"body":    "done",
+			},
+		}
+
+		_, err := action.ToEmailAction()
+		gt.Error(t, err)
+	})
+
+	t.Run("ToNtfyAction defaults", func(t *testing.T) {
+		action := model.Action{
+			Type: "ntfy",
+			Data: map[string]interface{}{
+				"topic":   "ci",
+				"message": "done",
+			},
+		}
+
+		ntfyAction, err := action.ToNtfyAction()
+		gt.NoError(t, err)
+		gt.Equal(t, ntfyAction.Server, "https://ntfy.sh")
+		gt.Equal(t, ntfyAction.Title, "octap")
+		gt.Equal(t, ntfyAction.Priority, "")
+	})
+
+	t.Run("ToGotifyAction with invalid priority", func(t *testing.T) {
+		action := model.Action{
+			Type: "gotify",
+			Data: map[string]interface{}{
+				"server":   "https://gotify.example.com",
+				"token":    "token",
+				"message":  "done",
+				"priority": "loud",
+			},
+		}
+
+		_, err := action.ToGotifyAction()
+		gt.Error(t, err)
+	})
+
+	t.Run("ToPushoverAction without user", func(t *testing.T) {
+		action := model.Action{
+			Type: "pushover",
+			Data: map[string]interface{}{
+				"token":   "token",
+				"message": "done",
+			},
+		}
+
+		_, err := action.ToPushoverAction()
+		gt.Error(t, err)
+	})
 }
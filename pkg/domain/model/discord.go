@@ -0,0 +1,50 @@
+package model
+
+// MessageField is a name/value pair shown in rich chat messages. Value is a template.
+type MessageField struct {
+	Name   string `yaml:"name"`
+	Value  string `yaml:"value"`
+	Inline bool   `yaml:"inline,omitempty"`
+}
+
+// DiscordAction represents a Discord webhook notification action
+type DiscordAction struct {
+	WebhookURL string         `yaml:"webhook_url"`
+	Title      string         `yaml:"title,omitempty"` // template
+	Message    string         `yaml:"message"`         // template
+	Color      string         `yaml:"color,omitempty"` // good, warning, danger, or #hex; defaults by event type
+	Fields     []MessageField `yaml:"fields,omitempty"`
+	UserName   string         `yaml:"username,omitempty"`
+	AvatarURL  string         `yaml:"avatar_url,omitempty"`
+}
+
+// DiscordPayload represents the JSON payload for Discord webhook
+type DiscordPayload struct {
+	Content   string         `json:"content,omitempty"`
+	UserName  string         `json:"username,omitempty"`
+	AvatarURL string         `json:"avatar_url,omitempty"`
+	Embeds    []DiscordEmbed `json:"embeds,omitempty"`
+}
+
+// DiscordEmbed represents a Discord message embed
+type DiscordEmbed struct {
+	Title       string              `json:"title,omitempty"`
+	Description string              `json:"description,omitempty"`
+	URL         string              `json:"url,omitempty"`
+	Color       int                 `json:"color,omitempty"`
+	Timestamp   string              `json:"timestamp,omitempty"` // ISO8601
+	Fields      []DiscordEmbedField `json:"fields,omitempty"`
+	Footer      *DiscordEmbedFooter `json:"footer,omitempty"`
+}
+
+// DiscordEmbedField represents a field of a Discord embed
+type DiscordEmbedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline,omitempty"`
+}
+
+// DiscordEmbedFooter represents the footer of a Discord embed
+type DiscordEmbedFooter struct {
+	Text string `json:"text"`
+}
@@ -0,0 +1,25 @@
+package model
+
+// EmailSecurity selects how the SMTP connection is secured
+type EmailSecurity string
+
+const (
+	EmailSecuritySTARTTLS EmailSecurity = "starttls"
+	EmailSecurityTLS      EmailSecurity = "tls" // implicit TLS, usually port 465
+	EmailSecurityNone     EmailSecurity = "none"
+)
+
+// EmailAction represents an email notification action sent over SMTP
+type EmailAction struct {
+	Host     string        `yaml:"host"`
+	Port     int           `yaml:"port,omitempty"` // defaults to 587, 465 or 25 by security
+	Security EmailSecurity `yaml:"security,omitempty"`
+	Username string        `yaml:"username,omitempty"` // supports environment variables
+	Password string        `yaml:"password,omitempty"` // supports environment variables
+	From     string        `yaml:"from"`
+	To       []string      `yaml:"to"`
+	Cc       []string      `yaml:"cc,omitempty"`
+	Subject  string        `yaml:"subject"`        // template
+	Body     string        `yaml:"body"`           // text template
+	HTML     string        `yaml:"html,omitempty"` // HTML template, sent as an alternative to Body
+}
@@ -1,5 +1,10 @@
 package model
 
+import (
+	"strings"
+	"time"
+)
+
 // HookEvent represents a type of workflow event
 type HookEvent string
 
@@ -8,8 +13,52 @@ const (
 	HookCheckFailure    HookEvent = "check_failure"
 	HookCompleteSuccess HookEvent = "complete_success"
 	HookCompleteFailure HookEvent = "complete_failure"
+	HookMonitorAborted  HookEvent = "monitor_aborted"
+
+	// HookRunStarted fires when a run starts executing
+	HookRunStarted HookEvent = "run_started"
+	// HookRunQueuedTooLong fires once when a run has been queued longer than the queued timeout
+	HookRunQueuedTooLong HookEvent = "run_queued_too_long"
+	HookCheckCancelled   HookEvent = "check_cancelled"
+	HookCheckTimedOut    HookEvent = "check_timed_out"
+	HookCheckSkipped     HookEvent = "check_skipped"
+	// HookFirstFailure fires with the first check_failure of the commit
+	HookFirstFailure HookEvent = "first_failure"
+	// HookRecovered fires when a workflow that failed on the previous commit of the branch passes
+	HookRecovered HookEvent = "recovered"
 )
 
+// HookEvents lists every hook event in the order they appear in the configuration file
+var HookEvents = []HookEvent{
+	HookCheckSuccess,
+	HookCheckFailure,
+	HookCompleteSuccess,
+	HookCompleteFailure,
+	HookMonitorAborted,
+	HookRunStarted,
+	HookRunQueuedTooLong,
+	HookCheckCancelled,
+	HookCheckTimedOut,
+	HookCheckSkipped,
+	HookFirstFailure,
+	HookRecovered,
+}
+
+// IsCheck reports whether the event reports the conclusion of a completed run
+func (e HookEvent) IsCheck() bool {
+	switch e {
+	case HookCheckSuccess, HookCheckFailure, HookCheckCancelled, HookCheckTimedOut, HookCheckSkipped:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsSummary reports whether the event describes the whole commit rather than a single run
+func (e HookEvent) IsSummary() bool {
+	return e == HookCompleteSuccess || e == HookCompleteFailure || e == HookMonitorAborted
+}
+
 // WorkflowEvent contains information about a workflow event
 type WorkflowEvent struct {
 	Type       HookEvent
@@ -17,4 +66,63 @@ type WorkflowEvent struct {
 	Workflow   string
 	RunID      int64
 	URL        string
+	// Duration is the run duration for check events and the monitoring duration for complete and aborted events
+	Duration time.Duration
+	// ETA is the estimated time when all workflows of the commit complete, zero if unknown
+	ETA time.Time
+	// Runs are the workflow runs of the commit when the event fired
+	Runs []*WorkflowRun
+	// Commit is the monitored commit
+	Commit Commit
+	// Summary holds the result counts of complete and aborted events, nil for check events
+	Summary *Summary
+	// Outputs are the outputs of the actions of this event that finished earlier, keyed by action ID
+	Outputs map[string]map[string]string
+	// Digest holds the runs of check events collapsed into this event by the
+	// digest window, nil for events that were not collapsed
+	Digest []*WorkflowRun
+}
+
+// Commit describes the commit whose workflow runs are monitored
+type Commit struct {
+	SHA     string `json:"sha"`
+	Branch  string `json:"branch,omitempty"`
+	Message string `json:"message,omitempty"`
+	// Author is the name of the commit author
+	Author string `json:"author,omitempty"`
+	// Actor is the GitHub login of the user who triggered the workflow runs
+	Actor string `json:"actor,omitempty"`
+}
+
+// NewCommit collects commit details from workflow runs of the commit. Earlier
+// runs take precedence, and sha is used when the runs don't carry one.
+func NewCommit(sha string, runs []*WorkflowRun) Commit {
+	commit := Commit{SHA: sha}
+	for _, run := range runs {
+		if run == nil {
+			continue
+		}
+		if commit.SHA == "" {
+			commit.SHA = run.HeadSHA
+		}
+		if commit.Branch == "" {
+			commit.Branch = run.Branch
+		}
+		if commit.Message == "" {
+			commit.Message = run.CommitMessage
+		}
+		if commit.Author == "" {
+			commit.Author = run.CommitAuthor
+		}
+		if commit.Actor == "" {
+			commit.Actor = run.Actor
+		}
+	}
+	return commit
+}
+
+// Title returns the first line of the commit message
+func (c Commit) Title() string {
+	title, _, _ := strings.Cut(c.Message, "\n")
+	return strings.TrimSpace(title)
 }
@@ -0,0 +1,25 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/m-mizutani/gt"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+)
+
+func TestNewCommit(t *testing.T) {
+	runs := []*model.WorkflowRun{
+		{HeadSHA: "abc1234", Branch: "main", Actor: "octocat"},
+		{HeadSHA: "abc1234", Branch: "main", Actor: "dependabot", CommitMessage: "Fix build\n\nDetails", CommitAuthor: "Mona"},
+	}
+
+	commit := model.NewCommit("", runs)
+	gt.Equal(t, commit.SHA, "abc1234")
+	gt.Equal(t, commit.Branch, "main")
+	// Earlier runs take precedence
+	gt.Equal(t, commit.Actor, "octocat")
+	gt.Equal(t, commit.Author, "Mona")
+	gt.Equal(t, commit.Title(), "Fix build")
+
+	gt.Equal(t, model.NewCommit("def5678", runs).SHA, "def5678")
+}
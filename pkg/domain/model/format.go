@@ -0,0 +1,31 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// shortSHALength is the length of abbreviated commit SHAs, as shown by git and GitHub
+const shortSHALength = 7
+
+// ShortSHA abbreviates a commit SHA for display in the terminal and hook messages
+func ShortSHA(sha string) string {
+	if len(sha) > shortSHALength {
+		return sha[:shortSHALength]
+	}
+	return sha
+}
+
+// FormatDuration formats a duration for display, e.g. 45s, 3m 5s or 1h 2m.
+// Negative durations are shown as 0s.
+func FormatDuration(d time.Duration) string {
+	d = max(d, 0).Round(time.Second)
+	switch {
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+	case d >= time.Minute:
+		return fmt.Sprintf("%dm %ds", int(d.Minutes()), int(d.Seconds())%60)
+	default:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+}
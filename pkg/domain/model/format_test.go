@@ -0,0 +1,28 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m-mizutani/gt"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+)
+
+func TestShortSHA(t *testing.T) {
+	gt.Equal(t, model.ShortSHA("0123456789abcdef"), "0123456")
+	gt.Equal(t, model.ShortSHA("abc"), "abc")
+}
+
+func TestFormatDuration(t *testing.T) {
+	testCases := map[time.Duration]string{
+		-time.Second:                            "0s",
+		0:                                       "0s",
+		1400 * time.Millisecond:                 "1s",
+		45 * time.Second:                        "45s",
+		3*time.Minute + 5*time.Second:           "3m 5s",
+		time.Hour + 2*time.Minute + time.Second: "1h 2m",
+	}
+	for d, expected := range testCases {
+		gt.Equal(t, model.FormatDuration(d), expected)
+	}
+}
@@ -0,0 +1,70 @@
+package model
+
+import "time"
+
+// SessionConclusion represents the overall result of a monitoring session
+type SessionConclusion string
+
+const (
+	SessionConclusionSuccess SessionConclusion = "success"
+	SessionConclusionFailure SessionConclusion = "failure"
+)
+
+// Session is a record of one monitoring session kept in the local history
+type Session struct {
+	Repository string            `json:"repository"`
+	CommitSHA  string            `json:"commit_sha"`
+	Branch     string            `json:"branch,omitempty"`
+	StartedAt  time.Time         `json:"started_at"`
+	FinishedAt time.Time         `json:"finished_at"`
+	Conclusion SessionConclusion `json:"conclusion"`
+	Runs       []*WorkflowRun    `json:"runs"`
+	Hooks      []SessionHook     `json:"hooks,omitempty"`
+}
+
+// SessionHook records a hook event fired during a monitoring session
+type SessionHook struct {
+	Event    HookEvent `json:"event"`
+	Workflow string    `json:"workflow,omitempty"`
+	FiredAt  time.Time `json:"fired_at"`
+}
+
+// FindRun returns the run of the named workflow in the session, or nil
+func (s *Session) FindRun(workflow string) *WorkflowRun {
+	for _, run := range s.Runs {
+		if run.Name == workflow {
+			return run
+		}
+	}
+	return nil
+}
+
+// HistoryFilter selects sessions from the local history. Empty fields match everything.
+type HistoryFilter struct {
+	Repository string
+	Branch     string
+	// Workflow restricts sessions to those containing the workflow. When set,
+	// Conclusion is matched against that workflow's conclusion instead of the session's.
+	Workflow   string
+	Conclusion string
+	Since      time.Time
+	// Limit is the maximum number of sessions returned, newest first. Zero means no limit.
+	Limit int
+}
+
+// Duration returns the time from the first run being created to the last run finishing
+func (s *Session) Duration() time.Duration {
+	var first, last time.Time
+	for _, run := range s.Runs {
+		if first.IsZero() || run.CreatedAt.Before(first) {
+			first = run.CreatedAt
+		}
+		if run.UpdatedAt.After(last) {
+			last = run.UpdatedAt
+		}
+	}
+	if first.IsZero() || last.Before(first) {
+		return 0
+	}
+	return last.Sub(first)
+}
@@ -0,0 +1,29 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m-mizutani/gt"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+)
+
+func TestSession(t *testing.T) {
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	session := &model.Session{
+		Runs: []*model.WorkflowRun{
+			{Name: "build", CreatedAt: base, UpdatedAt: base.Add(2 * time.Minute)},
+			{Name: "test", CreatedAt: base.Add(time.Minute), UpdatedAt: base.Add(5 * time.Minute)},
+		},
+	}
+
+	t.Run("Duration spans all runs", func(t *testing.T) {
+		gt.Equal(t, session.Duration(), 5*time.Minute)
+		gt.Equal(t, (&model.Session{}).Duration(), time.Duration(0))
+	})
+
+	t.Run("FindRun", func(t *testing.T) {
+		gt.Equal(t, session.FindRun("test").Name, "test")
+		gt.True(t, session.FindRun("deploy") == nil)
+	})
+}
@@ -0,0 +1,42 @@
+package model
+
+import "time"
+
+// HookStatus is the outcome of a single hook action
+type HookStatus string
+
+const (
+	HookStatusSuccess HookStatus = "success"
+	HookStatusFailed  HookStatus = "failed"
+	// HookStatusSkipped is an action whose condition was not met or whose needs failed
+	HookStatusSkipped HookStatus = "skipped"
+)
+
+// HookResult records how a hook action went
+type HookResult struct {
+	Event    HookEvent
+	Workflow string
+	// Index is the position of the action in the event's list
+	Index    int
+	ID       string
+	Type     string
+	Status   HookStatus
+	Error    string
+	Duration time.Duration
+	// Retries is how many times the action resent a request after a failure
+	Retries int
+}
+
+// HookResults are the results of the hook actions run during a session
+type HookResults []HookResult
+
+// Count returns the number of results with the status
+func (r HookResults) Count(status HookStatus) int {
+	n := 0
+	for _, result := range r {
+		if result.Status == status {
+			n++
+		}
+	}
+	return n
+}
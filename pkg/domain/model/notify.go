@@ -0,0 +1,25 @@
+package model
+
+import "time"
+
+// NotifyUrgency is the urgency level of a desktop notification
+type NotifyUrgency string
+
+const (
+	NotifyUrgencyLow      NotifyUrgency = "low"
+	NotifyUrgencyNormal   NotifyUrgency = "normal"
+	NotifyUrgencyCritical NotifyUrgency = "critical"
+)
+
+// NotifyAction represents a desktop notification action
+type NotifyAction struct {
+	Title   string        `yaml:"title,omitempty"` // template, defaults to "octap"
+	Body    string        `yaml:"body"`            // template
+	Urgency NotifyUrgency `yaml:"urgency,omitempty"`
+	Icon    string        `yaml:"icon,omitempty"`   // icon name or path
+	Expire  time.Duration `yaml:"expire,omitempty"` // how long the notification is shown; zero uses the server default
+	// OpenRun adds a default action that opens the run URL when the notification is clicked
+	OpenRun bool `yaml:"open_run"`
+	// Wait keeps the action running to handle clicks, e.g. for complete events after which octap exits
+	Wait time.Duration `yaml:"wait,omitempty"`
+}
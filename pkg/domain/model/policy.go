@@ -0,0 +1,79 @@
+package model
+
+import (
+	"time"
+
+	"github.com/m-mizutani/goerr/v2"
+)
+
+// NotificationPolicy throttles and deduplicates hook actions
+type NotificationPolicy struct {
+	// RateLimit caps how often each action runs
+	RateLimit RateLimit `yaml:"rate_limit,omitempty"`
+	// DigestWindow collapses the check_* events fired within the window into
+	// one event per event type
+	DigestWindow time.Duration `yaml:"digest_window,omitempty"`
+	// Dedup fires the hooks of an event only in the first octap instance that
+	// monitors the commit
+	Dedup bool `yaml:"dedup,omitempty"`
+	// QuietHours suppress sound actions
+	QuietHours QuietHours `yaml:"quiet_hours,omitempty"`
+}
+
+// RateLimit allows an action to run Count times within Per
+type RateLimit struct {
+	Count int           `yaml:"count"`
+	Per   time.Duration `yaml:"per"`
+}
+
+// Enabled reports whether the limit is configured
+func (r RateLimit) Enabled() bool {
+	return r.Count > 0 && r.Per > 0
+}
+
+// QuietHours is a daily local time range in HH:MM. An End before Start spans midnight.
+type QuietHours struct {
+	Start string `yaml:"start"`
+	End   string `yaml:"end"`
+}
+
+// Enabled reports whether quiet hours are configured
+func (q QuietHours) Enabled() bool {
+	return q.Start != "" || q.End != ""
+}
+
+// Validate checks that both ends are valid times
+func (q QuietHours) Validate() error {
+	if _, err := parseClock(q.Start); err != nil {
+		return err
+	}
+	_, err := parseClock(q.End)
+	return err
+}
+
+// Contains reports whether t falls within the quiet hours
+func (q QuietHours) Contains(t time.Time) bool {
+	start, err := parseClock(q.Start)
+	if err != nil {
+		return false
+	}
+	end, err := parseClock(q.End)
+	if err != nil {
+		return false
+	}
+
+	now := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	if start <= end {
+		return now >= start && now < end
+	}
+	return now >= start || now < end
+}
+
+// parseClock parses HH:MM into the time since midnight
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, goerr.Wrap(err, "quiet hours must be HH:MM", goerr.V("value", s))
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
@@ -0,0 +1,51 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m-mizutani/gt"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+)
+
+func TestQuietHours(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2024, 1, 1, hour, minute, 0, 0, time.Local)
+	}
+
+	t.Run("within a day", func(t *testing.T) {
+		q := model.QuietHours{Start: "12:00", End: "13:30"}
+		gt.NoError(t, q.Validate())
+		gt.True(t, q.Contains(at(12, 0)))
+		gt.True(t, q.Contains(at(13, 29)))
+		gt.False(t, q.Contains(at(13, 30)))
+		gt.False(t, q.Contains(at(11, 59)))
+	})
+
+	t.Run("spanning midnight", func(t *testing.T) {
+		q := model.QuietHours{Start: "22:00", End: "07:00"}
+		gt.True(t, q.Contains(at(23, 0)))
+		gt.True(t, q.Contains(at(0, 0)))
+		gt.True(t, q.Contains(at(6, 59)))
+		gt.False(t, q.Contains(at(7, 0)))
+		gt.False(t, q.Contains(at(21, 59)))
+	})
+
+	t.Run("invalid time", func(t *testing.T) {
+		q := model.QuietHours{Start: "25:00", End: "07:00"}
+		gt.Error(t, q.Validate())
+		gt.False(t, q.Contains(at(1, 0)))
+		gt.Error(t, model.QuietHours{Start: "22:00"}.Validate())
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		gt.False(t, model.QuietHours{}.Enabled())
+		gt.True(t, model.QuietHours{Start: "22:00", End: "07:00"}.Enabled())
+	})
+}
+
+func TestRateLimitEnabled(t *testing.T) {
+	gt.False(t, model.RateLimit{}.Enabled())
+	gt.False(t, model.RateLimit{Count: 1}.Enabled())
+	gt.True(t, model.RateLimit{Count: 1, Per: time.Minute}.Enabled())
+}
@@ -0,0 +1,78 @@
+package model
+
+// PushPriority is the importance of a push notification. Each service maps it
+// to its own scale; when unset it is chosen from the event type.
+type PushPriority string
+
+const (
+	PushPriorityMin     PushPriority = "min"
+	PushPriorityLow     PushPriority = "low"
+	PushPriorityDefault PushPriority = "default"
+	PushPriorityHigh    PushPriority = "high"
+	PushPriorityUrgent  PushPriority = "urgent"
+)
+
+// NtfyAction represents a push notification published to an ntfy topic
+type NtfyAction struct {
+	Server   string       `yaml:"server,omitempty"` // defaults to https://ntfy.sh; supports environment variables
+	Topic    string       `yaml:"topic"`
+	Token    string       `yaml:"token,omitempty"` // access token, supports environment variables
+	Title    string       `yaml:"title,omitempty"` // template
+	Message  string       `yaml:"message"`         // template
+	Priority PushPriority `yaml:"priority,omitempty"`
+	Tags     []string     `yaml:"tags,omitempty"`
+}
+
+// NtfyPayload represents the JSON body published to ntfy
+type NtfyPayload struct {
+	Topic    string   `json:"topic"`
+	Title    string   `json:"title,omitempty"`
+	Message  string   `json:"message"`
+	Priority int      `json:"priority,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Click    string   `json:"click,omitempty"`
+}
+
+// GotifyAction represents a push notification sent to a Gotify server
+type GotifyAction struct {
+	Server   string       `yaml:"server"` // supports environment variables
+	Token    string       `yaml:"token"`  // application token, supports environment variables
+	Title    string       `yaml:"title,omitempty"`
+	Message  string       `yaml:"message"`
+	Priority PushPriority `yaml:"priority,omitempty"`
+}
+
+// GotifyPayload represents the JSON body of Gotify's create message API
+type GotifyPayload struct {
+	Title    string         `json:"title,omitempty"`
+	Message  string         `json:"message"`
+	Priority int            `json:"priority"`
+	Extras   map[string]any `json:"extras,omitempty"`
+}
+
+// PushoverAction represents a push notification sent through Pushover
+type PushoverAction struct {
+	Token    string       `yaml:"token"` // application token, supports environment variables
+	User     string       `yaml:"user"`  // user or group key, supports environment variables
+	Device   string       `yaml:"device,omitempty"`
+	Sound    string       `yaml:"sound,omitempty"`
+	Title    string       `yaml:"title,omitempty"`
+	Message  string       `yaml:"message"`
+	Priority PushPriority `yaml:"priority,omitempty"`
+}
+
+// PushoverPayload represents the JSON body of Pushover's messages API
+type PushoverPayload struct {
+	Token    string `json:"token"`
+	User     string `json:"user"`
+	Device   string `json:"device,omitempty"`
+	Sound    string `json:"sound,omitempty"`
+	Title    string `json:"title,omitempty"`
+	Message  string `json:"message"`
+	Priority int    `json:"priority"`
+	URL      string `json:"url,omitempty"`
+	URLTitle string `json:"url_title,omitempty"`
+	// Retry and Expire are required for emergency (priority 2) messages
+	Retry  int `json:"retry,omitempty"`
+	Expire int `json:"expire,omitempty"`
+}
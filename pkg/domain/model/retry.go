@@ -0,0 +1,43 @@
+package model
+
+import (
+	"time"
+
+	"github.com/m-mizutani/goerr/v2"
+)
+
+// RetryPolicy controls how a failed action is retried
+type RetryPolicy struct {
+	// Attempts is the total number of attempts including the first one
+	Attempts int `yaml:"attempts,omitempty"`
+	// Backoff is the wait before the first retry, doubled for each further retry
+	Backoff time.Duration `yaml:"backoff,omitempty"`
+}
+
+// RetryPolicy returns the optional 'retry' field of the action. Zero fields are
+// left for the hook executor to default.
+func (a *Action) RetryPolicy() (RetryPolicy, error) {
+	value, ok := a.Data["retry"]
+	if !ok {
+		return RetryPolicy{}, nil
+	}
+	return parseRetryPolicy(value, a.Type)
+}
+
+// Timeout returns the optional 'timeout' field of the action, which limits each
+// attempt. Zero is left for the hook executor to default.
+func (a *Action) Timeout() (time.Duration, error) {
+	value, ok := a.Data["timeout"]
+	if !ok {
+		return 0, nil
+	}
+
+	timeout, err := parseDuration(value, a.Type, "timeout")
+	if err != nil {
+		return 0, err
+	}
+	if timeout < 0 {
+		return 0, goerr.New("action 'timeout' must not be negative", goerr.V("type", a.Type), goerr.V("timeout", timeout))
+	}
+	return timeout, nil
+}
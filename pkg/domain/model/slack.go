@@ -1,20 +1,28 @@
 package model
 
-// SlackAction represents a Slack notification action
+import "encoding/json"
+
+// SlackAction represents a Slack notification action. It posts to an Incoming
+// Webhook, or with Token and Channel, through the Web API as a bot.
 type SlackAction struct {
-	WebhookURL string `yaml:"webhook_url"`
+	WebhookURL string `yaml:"webhook_url,omitempty"`
+	Token      string `yaml:"token,omitempty"`   // bot token (xoxb-...), supports environment variables
+	Channel    string `yaml:"channel,omitempty"` // channel ID or name, required with Token
 	Message    string `yaml:"message"`
-	Color      string `yaml:"color,omitempty"`      // good, warning, danger, or #hex
-	IconEmoji  string `yaml:"icon_emoji,omitempty"` // :emoji: format (only works if webhook allows customization)
-	UserName   string `yaml:"username,omitempty"`   // sender name (only works if webhook allows customization)
+	// Blocks is a Block Kit JSON template; Message is then the notification fallback text
+	Blocks    string `yaml:"blocks,omitempty"`
+	Color     string `yaml:"color,omitempty"`      // good, warning, danger, or #hex
+	IconEmoji string `yaml:"icon_emoji,omitempty"` // :emoji: format (only works if webhook allows customization)
+	UserName  string `yaml:"username,omitempty"`   // sender name (only works if webhook allows customization)
 }
 
 // SlackPayload represents the JSON payload for Slack webhook
 type SlackPayload struct {
-	Text        string       `json:"text"`
-	UserName    string       `json:"username,omitempty"`   // Only works if webhook allows customization
-	IconEmoji   string       `json:"icon_emoji,omitempty"` // Only works if webhook allows customization
-	Attachments []Attachment `json:"attachments,omitempty"`
+	Text        string          `json:"text"`
+	UserName    string          `json:"username,omitempty"`   // Only works if webhook allows customization
+	IconEmoji   string          `json:"icon_emoji,omitempty"` // Only works if webhook allows customization
+	Blocks      json.RawMessage `json:"blocks,omitempty"`
+	Attachments []Attachment    `json:"attachments,omitempty"`
 }
 
 // Attachment represents a Slack message attachment
@@ -34,3 +42,28 @@ type Field struct {
 	Value string `json:"value"`
 	Short bool   `json:"short"`
 }
+
+// BotMode reports whether the action posts through the Web API instead of a webhook
+func (a *SlackAction) BotMode() bool {
+	return a.Token != ""
+}
+
+// SlackMessage represents the request body of chat.postMessage and chat.update
+type SlackMessage struct {
+	Channel     string          `json:"channel"`
+	TS          string          `json:"ts,omitempty"`        // message to update, chat.update only
+	ThreadTS    string          `json:"thread_ts,omitempty"` // parent message of a threaded reply
+	Text        string          `json:"text"`
+	UserName    string          `json:"username,omitempty"`   // requires the chat:write.customize scope
+	IconEmoji   string          `json:"icon_emoji,omitempty"` // requires the chat:write.customize scope
+	Blocks      json.RawMessage `json:"blocks,omitempty"`
+	Attachments []Attachment    `json:"attachments,omitempty"`
+}
+
+// SlackAPIResponse represents the common fields of Slack Web API responses
+type SlackAPIResponse struct {
+	OK      bool   `json:"ok"`
+	Error   string `json:"error,omitempty"`
+	Channel string `json:"channel,omitempty"`
+	TS      string `json:"ts,omitempty"`
+}
@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// MonitorState is the progress of monitoring one commit, persisted so that a
+// restarted octap resumes without re-firing hooks it already fired
+type MonitorState struct {
+	Repository string    `json:"repository"`
+	CommitSHA  string    `json:"commit_sha"`
+	StartedAt  time.Time `json:"started_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	// Runs are the runs seen on the last check
+	Runs []*WorkflowRun `json:"runs"`
+	// NotifiedRunIDs are completed runs whose check_* hooks have been handled
+	NotifiedRunIDs []int64 `json:"notified_run_ids"`
+	// CompleteFired is set once the complete_* hook has fired for the commit
+	CompleteFired bool          `json:"complete_fired"`
+	Hooks         []SessionHook `json:"hooks,omitempty"`
+}
@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+// StatsSource selects where run history for statistics comes from
+type StatsSource string
+
+const (
+	StatsSourceAPI     StatsSource = "api"
+	StatsSourceHistory StatsSource = "history"
+)
+
+// WorkflowStats summarizes the recent health of one workflow
+type WorkflowStats struct {
+	Workflow string `json:"workflow"`
+	// TotalRuns counts completed runs including earlier attempts of re-run workflows
+	TotalRuns    int           `json:"total_runs"`
+	SuccessCount int           `json:"success_count"`
+	FailureCount int           `json:"failure_count"`
+	SuccessRate  float64       `json:"success_rate"`
+	P50Duration  time.Duration `json:"p50_duration"`
+	P95Duration  time.Duration `json:"p95_duration"`
+	P50QueueTime time.Duration `json:"p50_queue_time"`
+	// Commits is the number of distinct commits the workflow ran for
+	Commits int `json:"commits"`
+	// FlipFlops is the number of commits where the workflow both failed and succeeded
+	FlipFlops    int     `json:"flip_flops"`
+	FlipFlopRate float64 `json:"flip_flop_rate"`
+	Flaky        bool    `json:"flaky"`
+}
@@ -0,0 +1,57 @@
+package model
+
+// TeamsAction represents a Microsoft Teams notification action posting an
+// Adaptive Card to a Workflows or Incoming Webhook URL
+type TeamsAction struct {
+	WebhookURL string         `yaml:"webhook_url"`
+	Title      string         `yaml:"title,omitempty"` // template
+	Message    string         `yaml:"message"`         // template
+	Color      string         `yaml:"color,omitempty"` // good, warning, danger; defaults by event type
+	Fields     []MessageField `yaml:"fields,omitempty"`
+}
+
+// TeamsPayload represents the JSON payload for a Teams webhook
+type TeamsPayload struct {
+	Type        string            `json:"type"`
+	Attachments []TeamsAttachment `json:"attachments"`
+}
+
+// TeamsAttachment wraps an Adaptive Card in a Teams message
+type TeamsAttachment struct {
+	ContentType string       `json:"contentType"`
+	ContentURL  *string      `json:"contentUrl"`
+	Content     AdaptiveCard `json:"content"`
+}
+
+// AdaptiveCard represents an Adaptive Card
+type AdaptiveCard struct {
+	Schema  string                `json:"$schema"`
+	Type    string                `json:"type"`
+	Version string                `json:"version"`
+	Body    []AdaptiveCardElement `json:"body"`
+	Actions []AdaptiveCardAction  `json:"actions,omitempty"`
+}
+
+// AdaptiveCardElement is a TextBlock or FactSet element of an Adaptive Card
+type AdaptiveCardElement struct {
+	Type   string             `json:"type"`
+	Text   string             `json:"text,omitempty"`
+	Weight string             `json:"weight,omitempty"`
+	Size   string             `json:"size,omitempty"`
+	Color  string             `json:"color,omitempty"`
+	Wrap   bool               `json:"wrap,omitempty"`
+	Facts  []AdaptiveCardFact `json:"facts,omitempty"`
+}
+
+// AdaptiveCardFact is a title/value pair in a FactSet
+type AdaptiveCardFact struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+// AdaptiveCardAction is an action button of an Adaptive Card
+type AdaptiveCardAction struct {
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
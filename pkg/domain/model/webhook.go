@@ -0,0 +1,12 @@
+package model
+
+// WebhookAction represents a generic HTTP request action
+type WebhookAction struct {
+	URL     string            `yaml:"url"`
+	Method  string            `yaml:"method,omitempty"`  // defaults to POST
+	Headers map[string]string `yaml:"headers,omitempty"` // values support environment variables
+	// Body is a template; when empty, the event is sent as a JSON document
+	Body string `yaml:"body,omitempty"`
+	// Secret signs the body with HMAC-SHA256 in the X-Octap-Signature header
+	Secret string `yaml:"secret,omitempty"`
+}
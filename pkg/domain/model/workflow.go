@@ -21,20 +21,130 @@ const (
 )
 
 type WorkflowRun struct {
-	ID         int64
-	Name       string
-	Repository string
-	Status     WorkflowStatus
-	Conclusion WorkflowConclusion
-	URL        string
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
+	ID         int64  `json:"id"`
+	WorkflowID int64  `json:"workflow_id,omitempty"`
+	Name       string `json:"name"`
+	Repository string `json:"repository,omitempty"`
+	Branch     string `json:"branch,omitempty"`
+	HeadSHA    string `json:"head_sha,omitempty"`
+	// CommitMessage and CommitAuthor describe the head commit of the run
+	CommitMessage string `json:"commit_message,omitempty"`
+	CommitAuthor  string `json:"commit_author,omitempty"`
+	// Actor is the GitHub login of the user who triggered the run
+	Actor      string             `json:"actor,omitempty"`
+	RunAttempt int                `json:"run_attempt,omitempty"`
+	Status     WorkflowStatus     `json:"status"`
+	Conclusion WorkflowConclusion `json:"conclusion,omitempty"`
+	URL        string             `json:"url"`
+	CreatedAt  time.Time          `json:"created_at"`
+	UpdatedAt  time.Time          `json:"updated_at"`
+	// StartedAt is when the run (or its latest attempt) started executing
+	StartedAt time.Time `json:"started_at,omitempty"`
+	// EstimatedDuration is the expected total duration based on recent successful runs of the same workflow
+	EstimatedDuration time.Duration `json:"estimated_duration,omitempty"`
+}
+
+// Elapsed returns how long the run has been running, or its total duration once completed
+func (r *WorkflowRun) Elapsed(now time.Time) time.Duration {
+	if r.CreatedAt.IsZero() {
+		return 0
+	}
+	if r.Status == WorkflowStatusCompleted {
+		return r.UpdatedAt.Sub(r.CreatedAt)
+	}
+	return now.Sub(r.CreatedAt)
+}
+
+// Remaining returns the estimated time left until the run completes. ok is false
+// when the run has already completed or there is no estimate. A run that takes
+// longer than estimated has zero remaining time.
+func (r *WorkflowRun) Remaining(now time.Time) (remaining time.Duration, ok bool) {
+	if r.Status == WorkflowStatusCompleted || r.EstimatedDuration == 0 || r.CreatedAt.IsZero() {
+		return 0, false
+	}
+	remaining = r.EstimatedDuration - r.Elapsed(now)
+	if remaining < 0 {
+		remaining = 0
+	}
+	return remaining, true
+}
+
+// QueueTime returns how long the run waited before it started executing.
+// Re-run attempts keep the creation time of the first attempt, so they report zero.
+func (r *WorkflowRun) QueueTime() time.Duration {
+	if r.RunAttempt > 1 || r.StartedAt.IsZero() || r.CreatedAt.IsZero() || r.StartedAt.Before(r.CreatedAt) {
+		return 0
+	}
+	return r.StartedAt.Sub(r.CreatedAt)
+}
+
+// RunDuration returns how long a completed run took to execute, excluding queue time when known
+func (r *WorkflowRun) RunDuration() time.Duration {
+	start := r.StartedAt
+	if start.IsZero() {
+		start = r.CreatedAt
+	}
+	if start.IsZero() || r.UpdatedAt.Before(start) {
+		return 0
+	}
+	return r.UpdatedAt.Sub(start)
+}
+
+// EstimateCompletion returns the estimated time when all runs will have
+// completed, based on runs that have an estimate. It returns zero time when
+// no running workflow has an estimate.
+func EstimateCompletion(runs []*WorkflowRun, now time.Time) time.Time {
+	var eta time.Time
+	for _, run := range runs {
+		remaining, ok := run.Remaining(now)
+		if !ok {
+			continue
+		}
+		if finish := now.Add(remaining); finish.After(eta) {
+			eta = finish
+		}
+	}
+	return eta
 }
 
 type Summary struct {
-	TotalRuns    int
-	SuccessCount int
-	FailureCount int
-	OtherCount   int
-	Duration     time.Duration
+	TotalRuns    int `json:"total_runs"`
+	SuccessCount int `json:"success_count"`
+	FailureCount int `json:"failure_count"`
+	OtherCount   int `json:"other_count"`
+	// PendingCount is the number of runs not completed, non-zero only when monitoring was aborted
+	PendingCount int           `json:"pending_count,omitempty"`
+	Duration     time.Duration `json:"duration"`
+	// Runs are the workflow runs the counts were taken from
+	Runs []*WorkflowRun `json:"runs,omitempty"`
+}
+
+// RunFilter selects workflow runs when listing a repository's history
+type RunFilter struct {
+	Branch     string
+	WorkflowID int64
+	// Limit is the maximum number of runs returned, newest first
+	Limit int
+}
+
+// Progress describes the overall state of the monitored commit when an event occurs
+type Progress struct {
+	Completed int
+	Total     int
+	// ETA is the estimated time when all workflows complete, zero if unknown
+	ETA time.Time
+	// Runs are all workflow runs of the commit as of this check
+	Runs []*WorkflowRun
+}
+
+// WorkflowJob represents a single job within a workflow run
+type WorkflowJob struct {
+	ID          int64              `json:"id"`
+	RunID       int64              `json:"run_id"`
+	Name        string             `json:"name"`
+	Status      WorkflowStatus     `json:"status"`
+	Conclusion  WorkflowConclusion `json:"conclusion,omitempty"`
+	URL         string             `json:"url"`
+	StartedAt   time.Time          `json:"started_at"`
+	CompletedAt time.Time          `json:"completed_at"`
 }
@@ -45,3 +45,59 @@ func TestSummary(t *testing.T) {
 		gt.Equal(t, summary.Duration, 30*time.Minute)
 	})
 }
+
+func TestWorkflowRunEstimates(t *testing.T) {
+	now := time.Now()
+
+	t.Run("Elapsed of running and completed runs", func(t *testing.T) {
+		running := &model.WorkflowRun{
+			Status:    model.WorkflowStatusInProgress,
+			CreatedAt: now.Add(-2 * time.Minute),
+		}
+		gt.Equal(t, running.Elapsed(now), 2*time.Minute)
+
+		completed := &model.WorkflowRun{
+			Status:    model.WorkflowStatusCompleted,
+			CreatedAt: now.Add(-10 * time.Minute),
+			UpdatedAt: now.Add(-7 * time.Minute),
+		}
+		gt.Equal(t, completed.Elapsed(now), 3*time.Minute)
+	})
+
+	t.Run("Remaining uses estimated duration", func(t *testing.T) {
+		run := &model.WorkflowRun{
+			Status:            model.WorkflowStatusInProgress,
+			CreatedAt:         now.Add(-2 * time.Minute),
+			EstimatedDuration: 5 * time.Minute,
+		}
+		remaining, ok := run.Remaining(now)
+		gt.True(t, ok)
+		gt.Equal(t, remaining, 3*time.Minute)
+
+		overdue := &model.WorkflowRun{
+			Status:            model.WorkflowStatusInProgress,
+			CreatedAt:         now.Add(-10 * time.Minute),
+			EstimatedDuration: 5 * time.Minute,
+		}
+		remaining, ok = overdue.Remaining(now)
+		gt.True(t, ok)
+		gt.Equal(t, remaining, time.Duration(0))
+
+		noEstimate := &model.WorkflowRun{
+			Status:    model.WorkflowStatusInProgress,
+			CreatedAt: now,
+		}
+		_, ok = noEstimate.Remaining(now)
+		gt.False(t, ok)
+	})
+
+	t.Run("EstimateCompletion uses the latest finishing run", func(t *testing.T) {
+		runs := []*model.WorkflowRun{
+			{Status: model.WorkflowStatusInProgress, CreatedAt: now.Add(-time.Minute), EstimatedDuration: 3 * time.Minute},
+			{Status: model.WorkflowStatusQueued, CreatedAt: now, EstimatedDuration: 6 * time.Minute},
+			{Status: model.WorkflowStatusCompleted, CreatedAt: now, UpdatedAt: now, EstimatedDuration: time.Hour},
+		}
+		gt.Equal(t, model.EstimateCompletion(runs, now), now.Add(6*time.Minute))
+		gt.True(t, model.EstimateCompletion(nil, now).IsZero())
+	})
+}
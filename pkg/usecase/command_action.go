@@ -3,11 +3,14 @@ package usecase
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"os/exec"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
@@ -17,6 +20,9 @@ import (
 	"github.com/m-mizutani/octap/pkg/domain/model"
 )
 
+// commandOutputLimit is the number of bytes of stdout and stderr kept for logging
+const commandOutputLimit = 64 * 1024
+
 type commandAction struct{}
 
 // NewCommandAction creates a new CommandAction instance
@@ -26,6 +32,13 @@ func NewCommandAction() interfaces.ActionExecutor {
 
 // Execute runs a command
 func (c *commandAction) Execute(ctx context.Context, action model.Action, event model.WorkflowEvent) error {
+	_, err := c.ExecuteWithOutputs(ctx, action, event)
+	return err
+}
+
+// ExecuteWithOutputs runs a command and returns its outputs: the trimmed stdout
+// as "stdout", and the key=value lines the command wrote to $OCTAP_OUTPUT
+func (c *commandAction) ExecuteWithOutputs(ctx context.Context, action model.Action, event model.WorkflowEvent) (map[string]string, error) {
 	logger := ctxlog.From(ctx)
 
 	logger.Debug("commandAction.Execute called",
@@ -39,40 +52,178 @@ func (c *commandAction) Execute(ctx context.Context, action model.Action, event
 		logger.Error("Failed to parse command action",
 			slog.String("error", err.Error()),
 		)
-		return goerr.Wrap(err, "failed to parse command action")
+		return nil, goerr.Wrap(err, "failed to parse command action")
+	}
+
+	if err := c.renderAction(cmdAction, event); err != nil {
+		return nil, err
+	}
+
+	if out := dryRunOutput(ctx); out != nil {
+		return nil, c.writeDryRun(out, cmdAction, event)
 	}
 
 	// Prepare environment variables
 	env := c.prepareEnv(event)
-	if len(cmdAction.Env) > 0 {
-		env = append(env, cmdAction.Env...)
+
+	// Pass the full event as JSON when the command asks for it
+	var stdin []byte
+	if cmdAction.Stdin || cmdAction.EventFile {
+		payload, err := json.Marshal(newEventPayload(event, time.Now()))
+		if err != nil {
+			return nil, goerr.Wrap(err, "failed to marshal event")
+		}
+		if cmdAction.Stdin {
+			stdin = payload
+		}
+		if cmdAction.EventFile {
+			path, err := writeTempFile("octap-event-*.json", payload)
+			if err != nil {
+				return nil, err
+			}
+			defer removeTempFile(ctx, path)
+			env = append(env, "OCTAP_EVENT_FILE="+path)
+		}
+	}
+
+	// The command writes key=value outputs for later actions to this file
+	outputPath, err := writeTempFile("octap-output-*", nil)
+	if err != nil {
+		return nil, err
 	}
+	defer removeTempFile(ctx, outputPath)
+	env = append(env, "OCTAP_OUTPUT="+outputPath)
 
-	// Set default timeout if not specified
-	timeout := cmdAction.Timeout
-	if timeout == 0 {
-		timeout = 30 * time.Second
+	if len(cmdAction.Env) > 0 {
+		env = append(env, cmdAction.Env...)
 	}
 
 	// Execute command
-	err = c.executeCommand(ctx, cmdAction, env, timeout)
+	stdout, err := c.executeCommand(ctx, cmdAction, env, stdin)
 	if err != nil {
 		logger.Error("Command execution failed",
 			slog.String("command", cmdAction.Command),
 			slog.Any("args", cmdAction.Args),
-			slog.Duration("timeout", timeout),
 			slog.String("error", err.Error()),
 		)
-		return goerr.Wrap(err, "command execution failed")
+		return nil, goerr.Wrap(err, "command execution failed")
 	}
 
 	logger.Debug("Command executed successfully",
 		slog.String("command", cmdAction.Command),
 		slog.Any("args", cmdAction.Args),
 	)
+
+	outputs, err := readOutputFile(outputPath)
+	if err != nil {
+		return nil, err
+	}
+	outputs["stdout"] = strings.TrimSpace(stdout)
+	return outputs, nil
+}
+
+// renderAction renders the templates of the command, its arguments and extra environment variables
+func (c *commandAction) renderAction(cmdAction *model.CommandAction, event model.WorkflowEvent) error {
+	command, err := renderField("command", cmdAction.Command, event)
+	if err != nil {
+		return goerr.Wrap(err, "failed to build command")
+	}
+	cmdAction.Command = command
+
+	args := make([]string, len(cmdAction.Args))
+	for i, arg := range cmdAction.Args {
+		if args[i], err = renderField("args", arg, event); err != nil {
+			return goerr.Wrap(err, "failed to build command argument", goerr.V("index", i))
+		}
+	}
+	cmdAction.Args = args
+
+	env := make([]string, len(cmdAction.Env))
+	for i, entry := range cmdAction.Env {
+		if env[i], err = renderTemplate("env", entry, event); err != nil {
+			return goerr.Wrap(err, "failed to build command environment", goerr.V("index", i))
+		}
+	}
+	cmdAction.Env = env
+
+	return nil
+}
+
+// writeDryRun prints the command line, extra environment variables and the event
+// JSON the command would receive
+func (c *commandAction) writeDryRun(w io.Writer, cmdAction *model.CommandAction, event model.WorkflowEvent) error {
+	line := []string{strconv.Quote(expandPath(cmdAction.Command))}
+	for _, arg := range cmdAction.Args {
+		line = append(line, strconv.Quote(arg))
+	}
+	_, _ = fmt.Fprintf(w, "$ %s\n", strings.Join(line, " "))
+	for _, entry := range cmdAction.Env {
+		_, _ = fmt.Fprintf(w, "env: %s\n", entry)
+	}
+
+	if !cmdAction.Stdin && !cmdAction.EventFile {
+		return nil
+	}
+	payload, err := json.MarshalIndent(newEventPayload(event, time.Now()), "", "  ")
+	if err != nil {
+		return goerr.Wrap(err, "failed to marshal event")
+	}
+	if cmdAction.Stdin {
+		_, _ = fmt.Fprintln(w, "stdin: event JSON")
+	}
+	if cmdAction.EventFile {
+		_, _ = fmt.Fprintln(w, "OCTAP_EVENT_FILE: event JSON")
+	}
+	_, _ = fmt.Fprintf(w, "\n%s\n", payload)
 	return nil
 }
 
+// writeTempFile writes data to a new temporary file readable only by the user
+func writeTempFile(pattern string, data []byte) (string, error) {
+	file, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", goerr.Wrap(err, "failed to create temporary file")
+	}
+	if _, err := file.Write(data); err != nil {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+		return "", goerr.Wrap(err, "failed to write temporary file", goerr.V("path", file.Name()))
+	}
+	if err := file.Close(); err != nil {
+		_ = os.Remove(file.Name())
+		return "", goerr.Wrap(err, "failed to close temporary file", goerr.V("path", file.Name()))
+	}
+	return file.Name(), nil
+}
+
+func removeTempFile(ctx context.Context, path string) {
+	if err := os.Remove(path); err != nil {
+		ctxlog.From(ctx).Warn("Failed to remove temporary file",
+			slog.String("path", path),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// readOutputFile parses the key=value lines a command wrote to $OCTAP_OUTPUT.
+// Lines without "=" are ignored and later lines override earlier ones.
+func readOutputFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - path is a temporary file created by octap
+	if err != nil {
+		return nil, goerr.Wrap(err, "failed to read command outputs", goerr.V("path", path))
+	}
+
+	outputs := make(map[string]string)
+	for _, line := range strings.Split(string(data), "\n") {
+		key, value, ok := strings.Cut(strings.TrimRight(line, "\r"), "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			continue
+		}
+		outputs[strings.TrimSpace(key)] = value
+	}
+	return outputs, nil
+}
+
 // prepareEnv prepares environment variables for command execution
 func (c *commandAction) prepareEnv(event model.WorkflowEvent) []string {
 	// Start with current environment
@@ -85,6 +236,47 @@ func (c *commandAction) prepareEnv(event model.WorkflowEvent) []string {
 		"OCTAP_WORKFLOW":   event.Workflow,
 		"OCTAP_RUN_ID":     fmt.Sprintf("%d", event.RunID),
 		"OCTAP_RUN_URL":    event.URL,
+		"OCTAP_DURATION":   fmt.Sprintf("%d", int64(event.Duration.Seconds())),
+		"OCTAP_ETA":        "",
+		// Set by ExecuteWithOutputs when the event file is enabled
+		"OCTAP_EVENT_FILE": "",
+	}
+	if !event.ETA.IsZero() {
+		octapEnv["OCTAP_ETA"] = event.ETA.Format(time.RFC3339)
+	}
+
+	// Commit details
+	octapEnv["OCTAP_COMMIT_SHA"] = event.Commit.SHA
+	octapEnv["OCTAP_BRANCH"] = event.Commit.Branch
+	octapEnv["OCTAP_COMMIT_MESSAGE"] = event.Commit.Message
+	octapEnv["OCTAP_COMMIT_AUTHOR"] = event.Commit.Author
+	octapEnv["OCTAP_ACTOR"] = event.Commit.Actor
+
+	// Result counts are only known for complete and aborted events
+	for _, key := range []string{"OCTAP_TOTAL_RUNS", "OCTAP_SUCCESS_COUNT", "OCTAP_FAILURE_COUNT", "OCTAP_PENDING_COUNT", "OCTAP_FAILED_WORKFLOWS"} {
+		octapEnv[key] = ""
+	}
+	if event.Summary != nil {
+		octapEnv["OCTAP_TOTAL_RUNS"] = strconv.Itoa(event.Summary.TotalRuns)
+		octapEnv["OCTAP_SUCCESS_COUNT"] = strconv.Itoa(event.Summary.SuccessCount)
+		octapEnv["OCTAP_FAILURE_COUNT"] = strconv.Itoa(event.Summary.FailureCount)
+		octapEnv["OCTAP_PENDING_COUNT"] = strconv.Itoa(event.Summary.PendingCount)
+
+		var failed []string
+		for _, run := range event.Runs {
+			if run.Conclusion == model.WorkflowConclusionFailure {
+				failed = append(failed, run.Name)
+			}
+		}
+		octapEnv["OCTAP_FAILED_WORKFLOWS"] = strings.Join(failed, ",")
+	}
+
+	// The runs of the commit as a JSON array
+	octapEnv["OCTAP_RUNS"] = "[]"
+	if runs := newRunPayloads(event.Runs); runs != nil {
+		if data, err := json.Marshal(runs); err == nil {
+			octapEnv["OCTAP_RUNS"] = string(data)
+		}
 	}
 
 	for key, value := range octapEnv {
@@ -94,20 +286,14 @@ func (c *commandAction) prepareEnv(event model.WorkflowEvent) []string {
 	return env
 }
 
-// executeCommand executes the command with timeout
-func (c *commandAction) executeCommand(ctx context.Context, cmdAction *model.CommandAction, env []string, timeout time.Duration) error {
+// executeCommand executes the command until it exits or ctx is done, writing stdin to
+// its standard input when set, and returns the beginning of its standard output
+func (c *commandAction) executeCommand(ctx context.Context, cmdAction *model.CommandAction, env []string, stdin []byte) (string, error) {
 	logger := ctxlog.From(ctx)
 
-	// Create context with timeout
-	cmdCtx, cancel := context.WithTimeout(ctx, timeout)
-	defer cancel()
-
-	// Expand environment variables in command and args
+	// Templates and environment variables are already rendered by renderAction
 	command := expandPath(cmdAction.Command)
-	args := make([]string, len(cmdAction.Args))
-	for i, arg := range cmdAction.Args {
-		args[i] = os.ExpandEnv(arg)
-	}
+	args := cmdAction.Args
 
 	// Create command
 	var cmd *exec.Cmd
@@ -116,30 +302,34 @@ func (c *commandAction) executeCommand(ctx context.Context, cmdAction *model.Com
 		if strings.HasSuffix(strings.ToLower(command), ".ps1") {
 			// PowerShell script
 			psArgs := append([]string{"-ExecutionPolicy", "Bypass", "-File", command}, args...)
-			cmd = exec.CommandContext(cmdCtx, "powershell", psArgs...) // #nosec G204 - command is from config file
+			cmd = exec.CommandContext(ctx, "powershell", psArgs...) // #nosec G204 - command is from config file
 		} else {
 			// Regular command or batch file
-			cmd = exec.CommandContext(cmdCtx, command, args...) // #nosec G204 - command is from config file
+			cmd = exec.CommandContext(ctx, command, args...) // #nosec G204 - command is from config file
 		}
 	} else {
 		// Unix-like systems
-		cmd = exec.CommandContext(cmdCtx, command, args...) // #nosec G204 - command is from config file
+		cmd = exec.CommandContext(ctx, command, args...) // #nosec G204 - command is from config file
 	}
 
 	// Set environment
 	cmd.Env = env
 
-	// Capture output
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+
+	// Capture output, keeping only the beginning of large outputs
+	stdout := &limitedBuffer{limit: commandOutputLimit}
+	stderr := &limitedBuffer{limit: commandOutputLimit}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
 
 	// Log command execution
 	logger.Debug("Executing command",
 		slog.String("command", command),
 		slog.Any("args", args),
 		slog.String("os", runtime.GOOS),
-		slog.Duration("timeout", timeout),
 	)
 
 	// Run command
@@ -150,27 +340,57 @@ func (c *commandAction) executeCommand(ctx context.Context, cmdAction *model.Com
 		logger.Debug("Command stdout",
 			slog.String("command", command),
 			slog.String("stdout", stdout.String()),
+			slog.Bool("truncated", stdout.truncated),
 		)
 	}
 	if stderr.Len() > 0 {
 		logger.Debug("Command stderr",
 			slog.String("command", command),
 			slog.String("stderr", stderr.String()),
+			slog.Bool("truncated", stderr.truncated),
 		)
 	}
 
 	if err != nil {
-		// Check if it was a timeout
-		if cmdCtx.Err() == context.DeadlineExceeded {
-			return goerr.New(fmt.Sprintf("command timed out after %s", timeout))
+		// The hook executor reports how long the attempt was allowed to take
+		if ctx.Err() != nil {
+			return "", goerr.Wrap(ctx.Err(), "command was stopped")
 		}
 		// Include stderr in error message
 		errMsg := fmt.Sprintf("command failed: %v", err)
 		if stderr.Len() > 0 {
 			errMsg += fmt.Sprintf(", stderr: %s", stderr.String())
 		}
-		return goerr.New(errMsg)
+		return "", goerr.New(errMsg)
 	}
 
-	return nil
+	return stdout.String(), nil
+}
+
+// limitedBuffer keeps the first limit bytes written to it and discards the
+// rest, so a chatty command cannot grow the log without bound
+type limitedBuffer struct {
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.buf.Len(); room < len(p) {
+		b.truncated = true
+		if room > 0 {
+			b.buf.Write(p[:room])
+		}
+		// Report the full length so the command is not interrupted by a short write
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
+
+func (b *limitedBuffer) Len() int {
+	return b.buf.Len()
+}
+
+func (b *limitedBuffer) String() string {
+	return b.buf.String()
 }
@@ -2,9 +2,12 @@ package usecase_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
 	"time"
 
@@ -122,13 +125,19 @@ func TestCommandAction(t *testing.T) {
 			URL:        "https://github.com/test/repo/actions/runs/123",
 		}
 
-		// Execute - should timeout
-		cmdAction := usecase.NewCommandAction()
+		// Execute - should timeout, as the hook executor limits each attempt
+		executor := usecase.NewHookExecutor(&model.Config{Hooks: model.HooksConfig{
+			CheckSuccess: []model.Action{action},
+		}})
 		start := time.Now()
-		err := cmdAction.Execute(context.Background(), action, event)
+		gt.NoError(t, executor.Execute(context.Background(), event))
+		executor.WaitForCompletion()
 		duration := time.Since(start)
 
-		gt.Error(t, err)
+		results := executor.Results()
+		gt.A(t, results).Length(1).Required()
+		gt.Equal(t, results[0].Status, model.HookStatusFailed)
+		gt.True(t, strings.Contains(results[0].Error, "timed out after 100ms"))
 		// Should timeout quickly (less than 1 second)
 		gt.True(t, duration < 1*time.Second)
 	})
@@ -221,10 +230,10 @@ func TestCommandAction(t *testing.T) {
 				},
 			},
 			{
-				name: "invalid timeout format",
+				name: "invalid env type",
 				data: map[string]interface{}{
 					"command": "echo",
-					"timeout": "invalid",
+					"env":     "not an array",
 				},
 			},
 		}
@@ -344,6 +353,59 @@ echo "OCTAP_RUN_URL=$OCTAP_RUN_URL"`
 	// The actual output verification would require capturing stdout, which is logged
 }
 
+func TestCommandActionCompleteEventEnvironment(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script")
+	}
+
+	dir := t.TempDir()
+	outPath := filepath.Join(dir, "env.txt")
+	scriptPath := filepath.Join(dir, "dump_env.sh")
+	script := `#!/bin/sh
+{
+  echo "SHA=$OCTAP_COMMIT_SHA"
+  echo "BRANCH=$OCTAP_BRANCH"
+  echo "ACTOR=$OCTAP_ACTOR"
+  echo "TOTAL=$OCTAP_TOTAL_RUNS"
+  echo "FAILURES=$OCTAP_FAILURE_COUNT"
+  echo "FAILED=$OCTAP_FAILED_WORKFLOWS"
+  echo "RUNS=$OCTAP_RUNS"
+} > "$1"
+`
+	gt.NoError(t, os.WriteFile(scriptPath, []byte(script), 0700))
+
+	runs := []*model.WorkflowRun{
+		{ID: 1, Name: "build", Status: model.WorkflowStatusCompleted, Conclusion: model.WorkflowConclusionSuccess, URL: "https://example.com/1"},
+		{ID: 2, Name: "test", Status: model.WorkflowStatusCompleted, Conclusion: model.WorkflowConclusionFailure, URL: "https://example.com/2"},
+	}
+	event := model.WorkflowEvent{
+		Type:    model.HookCompleteFailure,
+		Runs:    runs,
+		Commit:  model.Commit{SHA: "abc1234", Branch: "main", Actor: "octocat"},
+		Summary: &model.Summary{TotalRuns: 2, SuccessCount: 1, FailureCount: 1, Runs: runs},
+	}
+	action := model.Action{
+		Type: "command",
+		Data: map[string]interface{}{
+			"command": scriptPath,
+			"args":    []interface{}{outPath},
+		},
+	}
+
+	gt.NoError(t, usecase.NewCommandAction().Execute(context.Background(), action, event)).Required()
+
+	out, err := os.ReadFile(outPath)
+	gt.NoError(t, err)
+	gt.Equal(t, string(out), `SHA=abc1234
+BRANCH=main
+ACTOR=octocat
+TOTAL=2
+FAILURES=1
+FAILED=test
+RUNS=[{"run_id":1,"name":"build","status":"completed","conclusion":"success","url":"https://example.com/1"},{"run_id":2,"name":"test","status":"completed","conclusion":"failure","url":"https://example.com/2"}]
+`)
+}
+
 func TestCommandActionIntegration(t *testing.T) {
 	t.Run("Chain multiple commands", func(t *testing.T) {
 		// Create temp directory for test
@@ -394,3 +456,148 @@ func TestCommandActionIntegration(t *testing.T) {
 		gt.NoError(t, err)
 	})
 }
+
+func TestCommandActionTemplateArgs(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script")
+	}
+
+	dir := t.TempDir()
+	outPath := filepath.Join(dir, "args.txt")
+	scriptPath := filepath.Join(dir, "dump_args.sh")
+	script := `#!/bin/sh
+for arg in "$@"; do echo "$arg"; done > "$OUT_PATH"
+`
+	gt.NoError(t, os.WriteFile(scriptPath, []byte(script), 0700)).Required()
+
+	action := model.Action{
+		Type: "command",
+		Data: map[string]interface{}{
+			"command": scriptPath,
+			"args": []interface{}{
+				"{{.Repository}}@{{shortSHA .Commit.SHA}}",
+				"{{upper .Commit.Branch}} {{emoji .EventType}}",
+				"{{.Commit.Author | default \"unknown\"}}",
+			},
+			"env": []interface{}{"OUT_PATH=" + outPath},
+		},
+	}
+	event := model.WorkflowEvent{
+		Type:       model.HookCheckFailure,
+		Repository: "owner/repo",
+		Commit:     model.Commit{SHA: "abcdef0123456789", Branch: "main"},
+	}
+
+	err := usecase.NewCommandAction().Execute(context.Background(), action, event)
+	gt.NoError(t, err).Required()
+
+	output, err := os.ReadFile(outPath)
+	gt.NoError(t, err).Required()
+	gt.Equal(t, string(output), "owner/repo@abcdef0\nMAIN ❌\nunknown\n")
+}
+
+func TestCommandActionEventJSON(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script")
+	}
+
+	dir := t.TempDir()
+	stdinPath := filepath.Join(dir, "stdin.json")
+	filePath := filepath.Join(dir, "file.json")
+	envPath := filepath.Join(dir, "event_file_path.txt")
+	scriptPath := filepath.Join(dir, "read_event.sh")
+	script := `#!/bin/sh
+cat > "$STDIN_PATH"
+cp "$OCTAP_EVENT_FILE" "$FILE_PATH"
+echo "$OCTAP_EVENT_FILE" > "$ENV_PATH"
+`
+	gt.NoError(t, os.WriteFile(scriptPath, []byte(script), 0700)).Required()
+
+	action := model.Action{
+		Type: "command",
+		Data: map[string]interface{}{
+			"command":    scriptPath,
+			"stdin":      true,
+			"event_file": true,
+			"env": []interface{}{
+				"STDIN_PATH=" + stdinPath,
+				"FILE_PATH=" + filePath,
+				"ENV_PATH=" + envPath,
+			},
+		},
+	}
+	event := model.WorkflowEvent{
+		Type:       model.HookCompleteFailure,
+		Repository: "owner/repo",
+		Duration:   90 * time.Second,
+		Commit:     model.Commit{SHA: "abc123", Branch: "main"},
+		Summary:    &model.Summary{TotalRuns: 2, SuccessCount: 1, FailureCount: 1},
+		Runs: []*model.WorkflowRun{
+			{ID: 1, Name: "CI", Status: model.WorkflowStatusCompleted, Conclusion: model.WorkflowConclusionSuccess},
+			{ID: 2, Name: "Lint", Status: model.WorkflowStatusCompleted, Conclusion: model.WorkflowConclusionFailure},
+		},
+	}
+
+	err := usecase.NewCommandAction().Execute(context.Background(), action, event)
+	gt.NoError(t, err).Required()
+
+	type payload struct {
+		EventType       string  `json:"event_type"`
+		Repository      string  `json:"repository"`
+		DurationSeconds float64 `json:"duration_seconds"`
+		Commit          struct {
+			SHA    string `json:"sha"`
+			Branch string `json:"branch"`
+		} `json:"commit"`
+		Summary struct {
+			TotalRuns    int `json:"total_runs"`
+			FailureCount int `json:"failure_count"`
+		} `json:"summary"`
+		Runs []struct {
+			Name       string `json:"name"`
+			Conclusion string `json:"conclusion"`
+		} `json:"runs"`
+	}
+
+	for _, path := range []string{stdinPath, filePath} {
+		data, err := os.ReadFile(path)
+		gt.NoError(t, err).Required()
+
+		var got payload
+		gt.NoError(t, json.Unmarshal(data, &got)).Required()
+		gt.Equal(t, got.EventType, "complete_failure")
+		gt.Equal(t, got.Repository, "owner/repo")
+		gt.Equal(t, got.DurationSeconds, 90.0)
+		gt.Equal(t, got.Commit.SHA, "abc123")
+		gt.Equal(t, got.Commit.Branch, "main")
+		gt.Equal(t, got.Summary.TotalRuns, 2)
+		gt.Equal(t, got.Summary.FailureCount, 1)
+		gt.A(t, got.Runs).Length(2)
+		gt.Equal(t, got.Runs[1].Name, "Lint")
+		gt.Equal(t, got.Runs[1].Conclusion, "failure")
+	}
+
+	// The event file is removed after the command finishes
+	eventFile, err := os.ReadFile(envPath)
+	gt.NoError(t, err).Required()
+	_, err = os.Stat(strings.TrimSpace(string(eventFile)))
+	gt.True(t, os.IsNotExist(err))
+}
+
+func TestCommandActionLargeOutput(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell command")
+	}
+
+	// Output beyond the capture limit is discarded without failing the command
+	action := model.Action{
+		Type: "command",
+		Data: map[string]interface{}{
+			"command": "sh",
+			"args":    []interface{}{"-c", "head -c 1000000 /dev/zero; head -c 1000000 /dev/zero >&2"},
+		},
+	}
+
+	err := usecase.NewCommandAction().Execute(context.Background(), action, model.WorkflowEvent{Type: model.HookCheckSuccess})
+	gt.NoError(t, err)
+}
@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"sync"
+	"time"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
+	"github.com/m-mizutani/goerr/v2"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+)
+
+// conditionEnv is the data available to the `when` condition of hook actions
+type conditionEnv struct {
+	Event      string `expr:"event"`
+	Repository string `expr:"repository"`
+	Workflow   string `expr:"workflow"`
+	Branch     string `expr:"branch"`
+	SHA        string `expr:"sha"`
+	Actor      string `expr:"actor"`
+	Author     string `expr:"author"`
+	// Conclusion is the conclusion of the run for check events, and success or
+	// failure of the commit for complete events
+	Conclusion string `expr:"conclusion"`
+	// Elapsed is the run or monitoring duration. It is not called duration, which
+	// is the builtin that parses literals, e.g. elapsed > duration("10m").
+	Elapsed time.Duration `expr:"elapsed"`
+	// FailedWorkflows are the names of the failed runs of the commit
+	FailedWorkflows []string `expr:"failed_workflows"`
+	TotalRuns       int      `expr:"total_runs"`
+	FailureCount    int      `expr:"failure_count"`
+	// Hour, Minute and Weekday are the local time when the event fired
+	Hour    int    `expr:"hour"`
+	Minute  int    `expr:"minute"`
+	Weekday string `expr:"weekday"`
+	// Outputs are the outputs of earlier actions of the event, e.g. outputs.upload.url != ""
+	Outputs map[string]map[string]string `expr:"outputs"`
+	// Digest are the names of the runs collapsed into a check event by the digest window
+	Digest []string `expr:"digest"`
+}
+
+func newConditionEnv(event model.WorkflowEvent, now time.Time) conditionEnv {
+	env := conditionEnv{
+		Event:      string(event.Type),
+		Repository: event.Repository,
+		Workflow:   event.Workflow,
+		Branch:     event.Commit.Branch,
+		SHA:        event.Commit.SHA,
+		Actor:      event.Commit.Actor,
+		Author:     event.Commit.Author,
+		Conclusion: eventConclusion(event),
+		Elapsed:    event.Duration,
+		Hour:       now.Hour(),
+		Minute:     now.Minute(),
+		Weekday:    now.Weekday().String(),
+		Outputs:    event.Outputs,
+	}
+
+	for _, run := range event.Runs {
+		if run.Conclusion == model.WorkflowConclusionFailure {
+			env.FailedWorkflows = append(env.FailedWorkflows, run.Name)
+		}
+	}
+	for _, run := range event.Digest {
+		env.Digest = append(env.Digest, run.Name)
+	}
+	if event.Summary != nil {
+		env.TotalRuns = event.Summary.TotalRuns
+		env.FailureCount = event.Summary.FailureCount
+	}
+
+	return env
+}
+
+// eventConclusion returns the conclusion of the run that fired a check event,
+// or the overall result of a complete event
+func eventConclusion(event model.WorkflowEvent) string {
+	for _, run := range event.Runs {
+		if run.ID == event.RunID && run.Conclusion != "" {
+			return string(run.Conclusion)
+		}
+	}
+
+	switch event.Type {
+	case model.HookCheckSuccess, model.HookCompleteSuccess, model.HookRecovered:
+		return string(model.WorkflowConclusionSuccess)
+	case model.HookCheckFailure, model.HookCompleteFailure, model.HookFirstFailure:
+		return string(model.WorkflowConclusionFailure)
+	case model.HookCheckCancelled:
+		return string(model.WorkflowConclusionCancelled)
+	case model.HookCheckTimedOut:
+		return string(model.WorkflowConclusionTimedOut)
+	case model.HookCheckSkipped:
+		return string(model.WorkflowConclusionSkipped)
+	default:
+		return ""
+	}
+}
+
+// compiledConditions caches the programs of `when` expressions, which are
+// evaluated for every event. Programs are safe to run concurrently.
+var compiledConditions sync.Map // map[string]*vm.Program
+
+// compileCondition compiles a `when` expression, which must evaluate to a boolean
+func compileCondition(when string) (*vm.Program, error) {
+	if program, ok := compiledConditions.Load(when); ok {
+		return program.(*vm.Program), nil
+	}
+
+	program, err := expr.Compile(when, expr.Env(conditionEnv{}), expr.AsBool())
+	if err != nil {
+		return nil, goerr.Wrap(err, "invalid when condition", goerr.V("when", when))
+	}
+	compiledConditions.Store(when, program)
+	return program, nil
+}
+
+// evaluateCondition reports whether an action with the `when` condition should run for the event
+func evaluateCondition(when string, event model.WorkflowEvent, now time.Time) (bool, error) {
+	if when == "" {
+		return true, nil
+	}
+
+	program, err := compileCondition(when)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := expr.Run(program, newConditionEnv(event, now))
+	if err != nil {
+		return false, goerr.Wrap(err, "failed to evaluate when condition", goerr.V("when", when))
+	}
+
+	matched, ok := result.(bool)
+	if !ok {
+		return false, goerr.New("when condition must evaluate to a boolean", goerr.V("when", when))
+	}
+	return matched, nil
+}
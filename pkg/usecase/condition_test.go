@@ -0,0 +1,89 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m-mizutani/gt"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+	"github.com/m-mizutani/octap/pkg/usecase"
+)
+
+func TestEvaluateCondition(t *testing.T) {
+	checkEvent := model.WorkflowEvent{
+		Type:       model.HookCheckFailure,
+		Repository: "owner/repo",
+		Workflow:   "CI",
+		RunID:      2,
+		Duration:   12 * time.Minute,
+		Commit:     model.Commit{SHA: "abc123", Branch: "main", Actor: "octocat"},
+		Runs: []*model.WorkflowRun{
+			{ID: 1, Name: "Lint", Status: model.WorkflowStatusCompleted, Conclusion: model.WorkflowConclusionSuccess},
+			{ID: 2, Name: "CI", Status: model.WorkflowStatusCompleted, Conclusion: model.WorkflowConclusionFailure},
+		},
+	}
+	completeEvent := model.WorkflowEvent{
+		Type:       model.HookCompleteFailure,
+		Repository: "owner/repo",
+		Commit:     model.Commit{Branch: "feature"},
+		Summary:    &model.Summary{TotalRuns: 2, FailureCount: 1},
+		Runs:       checkEvent.Runs,
+	}
+	// A Tuesday afternoon
+	afternoon := time.Date(2024, 1, 2, 14, 30, 0, 0, time.Local)
+	night := time.Date(2024, 1, 2, 23, 0, 0, 0, time.Local)
+
+	testCases := []struct {
+		name     string
+		when     string
+		event    model.WorkflowEvent
+		now      time.Time
+		expected bool
+	}{
+		{"empty condition", "", checkEvent, afternoon, true},
+		{"branch", `branch == "main"`, checkEvent, afternoon, true},
+		{"branch mismatch", `branch == "main"`, completeEvent, afternoon, false},
+		{"business hours", `branch == "main" && hour >= 9 && hour < 18`, checkEvent, afternoon, true},
+		{"outside business hours", `branch == "main" && hour >= 9 && hour < 18`, checkEvent, night, false},
+		{"elapsed", `elapsed > duration("10m")`, checkEvent, afternoon, true},
+		{"conclusion of run", `conclusion == "failure" && workflow == "CI"`, checkEvent, afternoon, true},
+		{"conclusion of commit", `conclusion == "failure"`, completeEvent, afternoon, true},
+		{"actor and repository", `actor == "octocat" && repository startsWith "owner/"`, checkEvent, afternoon, true},
+		{"failed workflows", `"CI" in failed_workflows && failure_count == 1`, completeEvent, afternoon, true},
+		{"weekday", `weekday not in ["Saturday", "Sunday"]`, checkEvent, afternoon, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			matched, err := usecase.EvaluateCondition(tc.when, tc.event, tc.now)
+			gt.NoError(t, err).Required()
+			gt.Equal(t, matched, tc.expected)
+		})
+	}
+
+	t.Run("unknown variable", func(t *testing.T) {
+		_, err := usecase.EvaluateCondition(`unknown == "main"`, checkEvent, afternoon)
+		gt.Error(t, err)
+	})
+
+	t.Run("non-boolean result", func(t *testing.T) {
+		_, err := usecase.EvaluateCondition(`branch`, checkEvent, afternoon)
+		gt.Error(t, err)
+	})
+
+	t.Run("compiled program is reused across events", func(t *testing.T) {
+		when := `conclusion == "failure"`
+		first, err := usecase.CompileCondition(when)
+		gt.NoError(t, err).Required()
+		second, err := usecase.CompileCondition(when)
+		gt.NoError(t, err).Required()
+		gt.True(t, first == second)
+
+		matched, err := usecase.EvaluateCondition(when, model.WorkflowEvent{Type: model.HookCheckFailure}, afternoon)
+		gt.NoError(t, err)
+		gt.True(t, matched)
+		matched, err = usecase.EvaluateCondition(when, model.WorkflowEvent{Type: model.HookCheckSuccess}, afternoon)
+		gt.NoError(t, err)
+		gt.False(t, matched)
+	})
+}
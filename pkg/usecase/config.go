@@ -16,11 +16,34 @@ type configService struct {
 	defaultPath string
 }
 
+// defaultConfigDir returns ~/.config/octap, where octap keeps its configuration
+// and data. It fails if the home directory cannot be determined, in which case
+// callers must not fall back to a path relative to the working directory.
+func defaultConfigDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", goerr.Wrap(err, "failed to determine home directory")
+	}
+	return filepath.Join(homeDir, ".config", "octap"), nil
+}
+
+// dataPath returns the path of name in dir, where an empty dir selects the
+// default ~/.config/octap directory
+func dataPath(dir, name string) (string, error) {
+	if dir == "" {
+		var err error
+		if dir, err = defaultConfigDir(); err != nil {
+			return "", err
+		}
+	}
+	return filepath.Join(dir, name), nil
+}
+
 // NewConfigService creates a new ConfigService instance
 func NewConfigService() interfaces.ConfigService {
 	var defaultPath string
-	if homeDir, err := os.UserHomeDir(); err == nil {
-		defaultPath = filepath.Join(homeDir, ".config", "octap", "config.yml")
+	if dir, err := defaultConfigDir(); err == nil {
+		defaultPath = filepath.Join(dir, "config.yml")
 	}
 	// If homeDir cannot be determined, defaultPath remains empty string
 	// LoadDefault will handle empty defaultPath appropriately
@@ -47,9 +70,136 @@ func (c *configService) Load(path string) (*model.Config, error) {
 		return nil, goerr.Wrap(err, "failed to parse config file")
 	}
 
+	if err := validateConfig(&config); err != nil {
+		return nil, goerr.Wrap(err, "invalid config file", goerr.V("path", expandedPath))
+	}
+
 	return &config, nil
 }
 
+// sampleEvent is rendered by templates when the configuration is validated
+var sampleEvent = model.WorkflowEvent{
+	Type:       model.HookCheckFailure,
+	Repository: "owner/repo",
+	Workflow:   "CI",
+	RunID:      1,
+	URL:        "https://github.com/owner/repo/actions/runs/1",
+	Runs: []*model.WorkflowRun{
+		{
+			ID:         1,
+			Name:       "CI",
+			Status:     model.WorkflowStatusCompleted,
+			Conclusion: model.WorkflowConclusionFailure,
+			URL:        "https://github.com/owner/repo/actions/runs/1",
+		},
+	},
+}
+
+// validateConfig checks hook actions whose mistakes would otherwise only show
+// up when the hook fires, such as unknown action types, template syntax errors,
+// invalid `when` conditions, `needs` of unknown actions, malformed `retry` and
+// `timeout` fields and Slack blocks that do not render to valid JSON
+func validateConfig(config *model.Config) error {
+	if err := validatePolicy(config.Hooks.Policy); err != nil {
+		return goerr.Wrap(err, "invalid notification policy")
+	}
+
+	for _, event := range model.HookEvents {
+		if err := validatePipeline(config.Hooks.Actions(event)); err != nil {
+			return goerr.Wrap(err, "invalid hook pipeline", goerr.V("event", event))
+		}
+		for i, action := range config.Hooks.Actions(event) {
+			if _, ok := actionFactories[action.Type]; !ok {
+				return goerr.New("unknown hook action type", goerr.V("event", event), goerr.V("index", i), goerr.V("type", action.Type))
+			}
+			if action.When != "" {
+				if _, err := compileCondition(action.When); err != nil {
+					return goerr.Wrap(err, "invalid condition in hook action", goerr.V("event", event), goerr.V("index", i))
+				}
+			}
+			if _, err := action.RetryPolicy(); err != nil {
+				return goerr.Wrap(err, "invalid retry in hook action", goerr.V("event", event), goerr.V("index", i))
+			}
+			if _, err := action.Timeout(); err != nil {
+				return goerr.Wrap(err, "invalid timeout in hook action", goerr.V("event", event), goerr.V("index", i))
+			}
+			if err := checkTemplates("", action.Data); err != nil {
+				return goerr.Wrap(err, "invalid template in hook action", goerr.V("event", event), goerr.V("index", i))
+			}
+
+			if action.Type != "slack" {
+				continue
+			}
+			// Other mistakes are reported when the action runs, as before
+			slackAction, err := action.ToSlackAction()
+			if err != nil || slackAction.Blocks == "" {
+				continue
+			}
+			if _, err := renderSlackBlocks(slackAction.Blocks, sampleEvent); err != nil {
+				return goerr.Wrap(err, "invalid slack blocks", goerr.V("event", event), goerr.V("index", i))
+			}
+		}
+	}
+
+	return nil
+}
+
+func validatePolicy(policy model.NotificationPolicy) error {
+	if policy.RateLimit.Count < 0 || policy.RateLimit.Per < 0 {
+		return goerr.New("rate limit must not be negative",
+			goerr.V("count", policy.RateLimit.Count), goerr.V("per", policy.RateLimit.Per))
+	}
+	if policy.RateLimit.Count > 0 && policy.RateLimit.Per == 0 {
+		return goerr.New("rate limit requires per", goerr.V("count", policy.RateLimit.Count))
+	}
+	if policy.DigestWindow < 0 {
+		return goerr.New("digest window must not be negative", goerr.V("digest_window", policy.DigestWindow))
+	}
+	if policy.QuietHours.Enabled() {
+		if err := policy.QuietHours.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// secretFields are credentials that are not templates, so they may contain "{{"
+var secretFields = map[string]bool{
+	"token":    true,
+	"user":     true,
+	"username": true,
+	"password": true,
+	"secret":   true,
+}
+
+// checkTemplates checks the template syntax of every string in an action's data
+func checkTemplates(name string, value any) error {
+	switch v := value.(type) {
+	case string:
+		return checkTemplate(name, v)
+	case []any:
+		for i, item := range v {
+			if err := checkTemplates(fmt.Sprintf("%s[%d]", name, i), item); err != nil {
+				return err
+			}
+		}
+	case map[string]any:
+		for key, item := range v {
+			if secretFields[key] {
+				continue
+			}
+			field := key
+			if name != "" {
+				field = name + "." + key
+			}
+			if err := checkTemplates(field, item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // LoadDefault loads configuration from the default path
 func (c *configService) LoadDefault() (*model.Config, error) {
 	if c.defaultPath == "" {
@@ -109,6 +259,12 @@ func (c *configService) GenerateTemplate() string {
 #   - check_failure: Triggered when a workflow check fails
 #   - complete_success: Triggered when all workflows complete successfully
 #   - complete_failure: Triggered when any workflow fails
+#   - monitor_aborted: Triggered when monitoring is interrupted (Ctrl-C) before all workflows complete
+#   - run_started: Triggered when a workflow run starts executing
+#   - run_queued_too_long: Triggered when a workflow run stays queued longer than --queued-timeout
+#   - check_cancelled, check_timed_out, check_skipped: Triggered when a workflow run ends with that conclusion
+#   - first_failure: Triggered with the first check_failure of the commit
+#   - recovered: Triggered when a workflow that failed on the previous commit of the branch passes
 
 hooks:
   # Individual workflow events
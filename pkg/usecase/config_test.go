@@ -5,8 +5,10 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/m-mizutani/gt"
+	"github.com/m-mizutani/octap/pkg/domain/model"
 	"github.com/m-mizutani/octap/pkg/usecase"
 )
 
@@ -205,4 +207,229 @@ hooks:
 		gt.Error(t, err)
 		gt.Equal(t, loadedPath, configPath) // Path should still be returned even on error
 	})
+
+	t.Run("Load validates slack blocks", func(t *testing.T) {
+		configService := usecase.NewConfigService()
+
+		validPath := filepath.Join(t.TempDir(), "valid.yml")
+		gt.NoError(t, os.WriteFile(validPath, []byte(`hooks:
+  check_failure:
+    - type: slack
+      webhook_url: https://hooks.slack.com/services/test
+      message: "{{.Workflow}} failed"
+      blocks: |
+        [{"type": "header", "text": {"type": "plain_text", "text": {{json .Workflow}}}}, {{runsSection .Runs}}]
+`), 0600))
+		_, err := configService.Load(validPath)
+		gt.NoError(t, err)
+
+		invalidPath := filepath.Join(t.TempDir(), "invalid.yml")
+		gt.NoError(t, os.WriteFile(invalidPath, []byte(`hooks:
+  check_failure:
+    - type: slack
+      webhook_url: https://hooks.slack.com/services/test
+      message: "{{.Workflow}} failed"
+      blocks: |
+        [{"type": "section", "text": {{.Workflow}}}]
+`), 0600))
+		_, err = configService.Load(invalidPath)
+		gt.Error(t, err)
+	})
+
+	t.Run("Load checks template syntax of action fields", func(t *testing.T) {
+		configService := usecase.NewConfigService()
+
+		validPath := filepath.Join(t.TempDir(), "valid.yml")
+		gt.NoError(t, os.WriteFile(validPath, []byte(`hooks:
+  complete_failure:
+    - type: command
+      command: notify-send
+      args: ["{{.Repository}}", "{{shortSHA .Commit.SHA}} failed in {{duration .Duration}}"]
+    - type: webhook
+      url: https://example.com/{{.Repository}}
+      headers:
+        X-Workflow: "{{.Workflow | default \"unknown\"}}"
+`), 0600))
+		_, err := configService.Load(validPath)
+		gt.NoError(t, err)
+
+		invalidPath := filepath.Join(t.TempDir(), "invalid.yml")
+		gt.NoError(t, os.WriteFile(invalidPath, []byte(`hooks:
+  complete_failure:
+    - type: webhook
+      url: https://example.com/hook
+      headers:
+        X-Workflow: "{{.Workflow"
+`), 0600))
+		_, err = configService.Load(invalidPath)
+		gt.Error(t, err)
+
+		unknownFuncPath := filepath.Join(t.TempDir(), "unknown.yml")
+		gt.NoError(t, os.WriteFile(unknownFuncPath, []byte(`hooks:
+  check_failure:
+    - type: command
+      command: echo
+      args: ["{{unknownFunc .Workflow}}"]
+`), 0600))
+		_, err = configService.Load(unknownFuncPath)
+		gt.Error(t, err)
+	})
+
+	t.Run("Load parses and checks when conditions", func(t *testing.T) {
+		configService := usecase.NewConfigService()
+
+		validPath := filepath.Join(t.TempDir(), "valid.yml")
+		gt.NoError(t, os.WriteFile(validPath, []byte(`hooks:
+  check_failure:
+    - type: sound
+      when: branch == "main" && hour >= 9 && hour < 18
+      path: /path/to/sound.mp3
+`), 0600))
+		config, err := configService.Load(validPath)
+		gt.NoError(t, err).Required()
+		gt.A(t, config.Hooks.CheckFailure).Length(1)
+		gt.Equal(t, config.Hooks.CheckFailure[0].When, `branch == "main" && hour >= 9 && hour < 18`)
+		_, hasWhen := config.Hooks.CheckFailure[0].Data["when"]
+		gt.False(t, hasWhen)
+
+		invalidPath := filepath.Join(t.TempDir(), "invalid.yml")
+		gt.NoError(t, os.WriteFile(invalidPath, []byte(`hooks:
+  check_failure:
+    - type: sound
+      when: brnch == "main"
+      path: /path/to/sound.mp3
+`), 0600))
+		_, err = configService.Load(invalidPath)
+		gt.Error(t, err)
+	})
+
+	t.Run("Load rejects unknown action types", func(t *testing.T) {
+		configService := usecase.NewConfigService()
+
+		path := filepath.Join(t.TempDir(), "typo.yml")
+		gt.NoError(t, os.WriteFile(path, []byte(`hooks:
+  check_failure:
+    - type: slak
+      webhook_url: https://hooks.slack.com/services/T/B/X
+      message: failed
+`), 0600))
+		_, err := configService.Load(path)
+		gt.Error(t, err)
+		gt.True(t, strings.Contains(err.Error(), "unknown hook action type"))
+	})
+
+	t.Run("Load rejects invalid retry and timeout", func(t *testing.T) {
+		configService := usecase.NewConfigService()
+
+		for name, field := range map[string]string{
+			"retry":   "retry: {attempts: 0}",
+			"backoff": "retry: {backoff: soon}",
+			"timeout": "timeout: forever",
+		} {
+			path := filepath.Join(t.TempDir(), name+".yml")
+			gt.NoError(t, os.WriteFile(path, []byte(`hooks:
+  check_failure:
+    - type: command
+      command: true
+      `+field+`
+`), 0600))
+			_, err := configService.Load(path)
+			gt.Error(t, err)
+		}
+
+		path := filepath.Join(t.TempDir(), "valid.yml")
+		gt.NoError(t, os.WriteFile(path, []byte(`hooks:
+  check_failure:
+    - type: command
+      command: true
+      timeout: 10s
+      retry:
+        attempts: 3
+        backoff: 2s
+`), 0600))
+		config, err := configService.Load(path)
+		gt.NoError(t, err).Required()
+		retry, err := config.Hooks.CheckFailure[0].RetryPolicy()
+		gt.NoError(t, err)
+		gt.Equal(t, retry, model.RetryPolicy{Attempts: 3, Backoff: 2 * time.Second})
+	})
+
+	t.Run("Load parses run lifecycle hook events", func(t *testing.T) {
+		configService := usecase.NewConfigService()
+
+		path := filepath.Join(t.TempDir(), "lifecycle.yml")
+		gt.NoError(t, os.WriteFile(path, []byte(`hooks:
+  run_started:
+    - type: sound
+      path: /path/to/start.mp3
+  check_cancelled:
+    - type: sound
+      path: /path/to/cancelled.mp3
+  recovered:
+    - type: sound
+      path: /path/to/recovered.mp3
+    - type: sound
+      path: /path/to/fanfare.mp3
+`), 0600))
+		config, err := configService.Load(path)
+		gt.NoError(t, err).Required()
+		gt.A(t, config.Hooks.Actions(model.HookRunStarted)).Length(1)
+		gt.A(t, config.Hooks.Actions(model.HookCheckCancelled)).Length(1)
+		gt.A(t, config.Hooks.Actions(model.HookRecovered)).Length(2)
+		gt.A(t, config.Hooks.Actions(model.HookFirstFailure)).Length(0)
+	})
+
+	t.Run("Load parses and checks hook pipelines", func(t *testing.T) {
+		configService := usecase.NewConfigService()
+
+		validPath := filepath.Join(t.TempDir(), "valid.yml")
+		gt.NoError(t, os.WriteFile(validPath, []byte(`hooks:
+  sequential: true
+  complete_failure:
+    - type: command
+      id: upload
+      command: ./upload.sh
+      continue_on_error: true
+    - type: slack
+      needs: [upload]
+      webhook_url: https://hooks.slack.com/services/test
+      message: "Logs: {{.Outputs.upload.url}}"
+`), 0600))
+		config, err := configService.Load(validPath)
+		gt.NoError(t, err).Required()
+		gt.True(t, config.Hooks.Sequential)
+		actions := config.Hooks.CompleteFailure
+		gt.A(t, actions).Length(2).Required()
+		gt.Equal(t, actions[0].ID, "upload")
+		gt.True(t, actions[0].ContinueOnError)
+		gt.Equal(t, actions[1].Needs, []string{"upload"})
+		_, hasNeeds := actions[1].Data["needs"]
+		gt.False(t, hasNeeds)
+
+		for name, hooks := range map[string]string{
+			"unknown need": `
+    - type: sound
+      needs: [missing]
+      path: /path/to/sound.mp3`,
+			"later need": `
+    - type: sound
+      needs: [later]
+      path: /path/to/sound.mp3
+    - type: sound
+      id: later
+      path: /path/to/sound.mp3`,
+			"duplicate id": `
+    - type: sound
+      id: same
+      path: /path/to/sound.mp3
+    - type: sound
+      id: same
+      path: /path/to/sound.mp3`,
+		} {
+			path := filepath.Join(t.TempDir(), "invalid.yml")
+			gt.NoError(t, os.WriteFile(path, []byte("hooks:\n  complete_failure:"+hooks+"\n"), 0600))
+			_, err := configService.Load(path)
+			gt.Error(t, err).Describef("%s should be rejected", name)
+		}
+	})
 }
@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/m-mizutani/ctxlog"
+	"github.com/m-mizutani/goerr/v2"
+	"github.com/m-mizutani/octap/pkg/domain/interfaces"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+)
+
+// discordColors maps named colors to Discord embed colors, matching Slack's attachment colors
+var discordColors = map[string]int{
+	"good":    0x2EB886,
+	"warning": 0xDAA038,
+	"danger":  0xA30200,
+}
+
+type discordAction struct {
+	httpClient *http.Client
+}
+
+// NewDiscordAction creates a new DiscordAction instance
+func NewDiscordAction() interfaces.ActionExecutor {
+	return &discordAction{
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// Execute sends an embed to a Discord webhook
+func (d *discordAction) Execute(ctx context.Context, action model.Action, event model.WorkflowEvent) error {
+	logger := ctxlog.From(ctx)
+
+	logger.Debug("discordAction.Execute called",
+		slog.String("event_type", string(event.Type)),
+	)
+
+	discordAction, err := action.ToDiscordAction()
+	if err != nil {
+		logger.Error("Failed to parse discord action",
+			slog.String("error", err.Error()),
+		)
+		return goerr.Wrap(err, "failed to parse discord action")
+	}
+
+	webhookURL, err := renderField("webhook_url", discordAction.WebhookURL, event)
+	if err != nil {
+		return goerr.Wrap(err, "failed to build webhook URL")
+	}
+	if webhookURL == "" {
+		return goerr.New("webhook URL is empty after expansion")
+	}
+
+	payload, err := d.buildPayload(discordAction, event)
+	if err != nil {
+		return err
+	}
+
+	err = postJSON(ctx, d.httpClient, webhookURL, payload)
+	if err != nil {
+		return goerr.Wrap(err, "failed to send discord notification", goerr.V("url", maskWebhookURL(webhookURL)))
+	}
+
+	logger.Debug("Discord notification sent successfully")
+	return nil
+}
+
+// buildPayload renders the templates into a Discord embed
+func (d *discordAction) buildPayload(action *model.DiscordAction, event model.WorkflowEvent) (*model.DiscordPayload, error) {
+	message, err := renderTemplate("message", action.Message, event)
+	if err != nil {
+		return nil, goerr.Wrap(err, "failed to build message")
+	}
+
+	title := ""
+	if action.Title != "" {
+		if title, err = renderTemplate("title", action.Title, event); err != nil {
+			return nil, goerr.Wrap(err, "failed to build title")
+		}
+	}
+
+	color, err := parseDiscordColor(messageColor(action.Color, event.Type))
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := renderMessageFields(action.Fields, event)
+	if err != nil {
+		return nil, err
+	}
+
+	embed := model.DiscordEmbed{
+		Title:       title,
+		Description: message,
+		URL:         event.URL,
+		Color:       color,
+		Timestamp:   time.Now().UTC().Format(time.RFC3339),
+		Footer:      &model.DiscordEmbedFooter{Text: fmt.Sprintf("octap - %s", event.Repository)},
+	}
+	for _, field := range fields {
+		embed.Fields = append(embed.Fields, model.DiscordEmbedField{
+			Name:   field.Name,
+			Value:  field.Value,
+			Inline: field.Inline,
+		})
+	}
+
+	return &model.DiscordPayload{
+		UserName:  action.UserName,
+		AvatarURL: action.AvatarURL,
+		Embeds:    []model.DiscordEmbed{embed},
+	}, nil
+}
+
+// parseDiscordColor converts a named or #hex color to Discord's integer color
+func parseDiscordColor(color string) (int, error) {
+	if c, ok := discordColors[color]; ok {
+		return c, nil
+	}
+
+	c, err := strconv.ParseInt(strings.TrimPrefix(color, "#"), 16, 32)
+	if err != nil {
+		return 0, goerr.Wrap(err, "invalid discord color", goerr.V("color", color))
+	}
+	return int(c), nil
+}
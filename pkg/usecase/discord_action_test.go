@@ -0,0 +1,82 @@
+package usecase_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/m-mizutani/gt"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+	"github.com/m-mizutani/octap/pkg/usecase"
+)
+
+func TestDiscordAction(t *testing.T) {
+	event := model.WorkflowEvent{
+		Type:       model.HookCheckFailure,
+		Repository: "owner/repo",
+		Workflow:   "test",
+		URL:        "https://github.com/owner/repo/actions/runs/1",
+	}
+
+	t.Run("sends an embed", func(t *testing.T) {
+		var received model.DiscordPayload
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gt.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		action := model.Action{
+			Type: "discord",
+			Data: map[string]interface{}{
+				"webhook_url": server.URL,
+				"title":       "{{.Workflow}} failed",
+				"message":     "in {{.Repository}}",
+				"fields": []interface{}{
+					map[string]interface{}{"name": "Run", "value": "{{.RunURL}}", "inline": true},
+				},
+			},
+		}
+
+		gt.NoError(t, usecase.NewDiscordAction().Execute(context.Background(), action, event))
+		gt.A(t, received.Embeds).Length(1)
+		embed := received.Embeds[0]
+		gt.Equal(t, embed.Title, "test failed")
+		gt.Equal(t, embed.Description, "in owner/repo")
+		gt.Equal(t, embed.URL, event.URL)
+		gt.Equal(t, embed.Color, 0xA30200) // danger for failures
+		gt.A(t, embed.Fields).Length(1)
+		gt.Equal(t, embed.Fields[0].Value, event.URL)
+		gt.True(t, embed.Fields[0].Inline)
+	})
+
+	t.Run("hex color", func(t *testing.T) {
+		var received model.DiscordPayload
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gt.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		}))
+		defer server.Close()
+
+		action := model.Action{
+			Type: "discord",
+			Data: map[string]interface{}{
+				"webhook_url": server.URL,
+				"message":     "done",
+				"color":       "#00ff00",
+			},
+		}
+
+		gt.NoError(t, usecase.NewDiscordAction().Execute(context.Background(), action, event))
+		gt.Equal(t, received.Embeds[0].Color, 0x00ff00)
+	})
+
+	t.Run("missing message", func(t *testing.T) {
+		action := model.Action{
+			Type: "discord",
+			Data: map[string]interface{}{"webhook_url": "https://example.com"},
+		}
+		gt.Error(t, usecase.NewDiscordAction().Execute(context.Background(), action, event))
+	})
+}
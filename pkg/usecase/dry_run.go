@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+)
+
+type dryRunKey struct{}
+
+// WithDryRun returns a context in which hook actions render their templates and
+// write the commands, requests and messages they would send to w instead of sending them
+func WithDryRun(ctx context.Context, w io.Writer) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, io.Writer(&lockedWriter{w: w}))
+}
+
+// dryRunOutput returns where a dry run writes to, or nil when actions run for real
+func dryRunOutput(ctx context.Context) io.Writer {
+	w, _ := ctx.Value(dryRunKey{}).(io.Writer)
+	return w
+}
+
+// withDryRunBuffer makes the actions of the context write their dry run to buf
+func withDryRunBuffer(ctx context.Context, buf *bytes.Buffer) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, io.Writer(buf))
+}
+
+// lockedWriter serializes writes so that concurrent actions don't interleave their output
+type lockedWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(p)
+}
+
+// credentialHeaderWords mark headers whose values are credentials, e.g.
+// Authorization, X-Gotify-Key and X-Api-Token
+var credentialHeaderWords = []string{"auth", "key", "token", "secret", "password", "cookie"}
+
+// writeDryRunRequest prints an HTTP request in place of sending it. The URL is
+// masked since webhook URLs are secrets themselves, and credential headers and
+// body fields are redacted, so that the output can be shared.
+func writeDryRunRequest(w io.Writer, method, url string, headers http.Header, body []byte) {
+	_, _ = fmt.Fprintf(w, "%s %s\n", method, maskWebhookURL(url))
+
+	keys := make([]string, 0, len(headers))
+	for key := range headers {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		for _, value := range headers[key] {
+			if credentialHeader(key) {
+				value = redacted
+			}
+			_, _ = fmt.Fprintf(w, "%s: %s\n", key, value)
+		}
+	}
+
+	if len(body) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintln(w)
+	body = redactBody(body)
+	var indented bytes.Buffer
+	if json.Indent(&indented, body, "", "  ") == nil {
+		body = indented.Bytes()
+	}
+	_, _ = w.Write(body)
+	if !bytes.HasSuffix(body, []byte("\n")) {
+		_, _ = fmt.Fprintln(w)
+	}
+}
+
+const redacted = "<redacted>"
+
+func credentialHeader(key string) bool {
+	key = strings.ToLower(key)
+	return slices.ContainsFunc(credentialHeaderWords, func(word string) bool {
+		return strings.Contains(key, word)
+	})
+}
+
+// redactBody replaces the credential fields of a JSON body, such as the token
+// and user key of Pushover. Other bodies are returned as they are.
+func redactBody(body []byte) []byte {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value any
+	if decoder.Decode(&value) != nil || !redactValue(value) {
+		return body
+	}
+
+	var redactedBody bytes.Buffer
+	encoder := json.NewEncoder(&redactedBody)
+	encoder.SetEscapeHTML(false)
+	if encoder.Encode(value) != nil {
+		return body
+	}
+	return redactedBody.Bytes()
+}
+
+// redactValue replaces credential fields in decoded JSON and reports whether it replaced any
+func redactValue(value any) bool {
+	found := false
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if _, ok := field.(string); ok && secretFields[strings.ToLower(key)] {
+				v[key] = redacted
+				found = true
+				continue
+			}
+			found = redactValue(field) || found
+		}
+	case []any:
+		for _, item := range v {
+			found = redactValue(item) || found
+		}
+	}
+	return found
+}
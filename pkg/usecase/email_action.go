@@ -0,0 +1,274 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/m-mizutani/ctxlog"
+	"github.com/m-mizutani/goerr/v2"
+	"github.com/m-mizutani/octap/pkg/domain/interfaces"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+)
+
+// emailTimeout bounds the whole SMTP conversation
+const emailTimeout = 30 * time.Second
+
+// emailMessage is a rendered email ready to be sent
+type emailMessage struct {
+	From    string
+	To      []string
+	Cc      []string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+type emailAction struct{}
+
+// NewEmailAction creates a new EmailAction instance
+func NewEmailAction() interfaces.ActionExecutor {
+	return &emailAction{}
+}
+
+// Execute sends an email over SMTP
+func (e *emailAction) Execute(ctx context.Context, action model.Action, event model.WorkflowEvent) error {
+	logger := ctxlog.From(ctx)
+
+	logger.Debug("emailAction.Execute called",
+		slog.String("event_type", string(event.Type)),
+	)
+
+	emailAction, err := action.ToEmailAction()
+	if err != nil {
+		logger.Error("Failed to parse email action",
+			slog.String("error", err.Error()),
+		)
+		return goerr.Wrap(err, "failed to parse email action")
+	}
+
+	msg, err := e.buildMessage(emailAction, event)
+	if err != nil {
+		return err
+	}
+
+	data, err := msg.encode(time.Now())
+	if err != nil {
+		return err
+	}
+
+	if out := dryRunOutput(ctx); out != nil {
+		_, _ = fmt.Fprintf(out, "SMTP %s (%s)\n\n", net.JoinHostPort(emailAction.Host, strconv.Itoa(emailAction.Port)), emailAction.Security)
+		_, _ = out.Write(data)
+		return nil
+	}
+
+	if err := e.send(ctx, emailAction, msg, data); err != nil {
+		return goerr.Wrap(err, "failed to send email",
+			goerr.V("host", emailAction.Host),
+			goerr.V("port", emailAction.Port),
+		)
+	}
+
+	logger.Debug("Email sent successfully",
+		slog.String("host", emailAction.Host),
+		slog.Int("recipients", len(msg.To)+len(msg.Cc)),
+	)
+	return nil
+}
+
+// buildMessage renders the subject and body templates
+func (e *emailAction) buildMessage(action *model.EmailAction, event model.WorkflowEvent) (*emailMessage, error) {
+	subject, err := renderTemplate("subject", action.Subject, event)
+	if err != nil {
+		return nil, goerr.Wrap(err, "failed to build email subject")
+	}
+
+	msg := &emailMessage{
+		From:    action.From,
+		To:      action.To,
+		Cc:      action.Cc,
+		Subject: subject,
+	}
+
+	if action.Body != "" {
+		if msg.Text, err = renderTemplate("body", action.Body, event); err != nil {
+			return nil, goerr.Wrap(err, "failed to build email body")
+		}
+	}
+	if action.HTML != "" {
+		if msg.HTML, err = renderHTMLTemplate("html", action.HTML, event); err != nil {
+			return nil, goerr.Wrap(err, "failed to build email HTML body")
+		}
+	}
+
+	return msg, nil
+}
+
+// send delivers the message, securing the connection according to the action
+func (e *emailAction) send(ctx context.Context, action *model.EmailAction, msg *emailMessage, data []byte) error {
+	from, err := mail.ParseAddress(msg.From)
+	if err != nil {
+		return goerr.Wrap(err, "invalid from address", goerr.V("from", msg.From))
+	}
+	var recipients []string
+	for _, addr := range append(append([]string{}, msg.To...), msg.Cc...) {
+		parsed, err := mail.ParseAddress(addr)
+		if err != nil {
+			return goerr.Wrap(err, "invalid recipient address", goerr.V("address", addr))
+		}
+		recipients = append(recipients, parsed.Address)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, emailTimeout)
+	defer cancel()
+
+	addr := net.JoinHostPort(action.Host, strconv.Itoa(action.Port))
+	tlsConfig := &tls.Config{ServerName: action.Host, MinVersion: tls.VersionTLS12}
+
+	var conn net.Conn
+	if action.Security == model.EmailSecurityTLS {
+		dialer := &tls.Dialer{Config: tlsConfig}
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	} else {
+		var dialer net.Dialer
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return goerr.Wrap(err, "failed to connect to SMTP server")
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, action.Host)
+	if err != nil {
+		_ = conn.Close()
+		return goerr.Wrap(err, "failed to start SMTP session")
+	}
+	defer client.Close()
+
+	if action.Security == model.EmailSecuritySTARTTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return goerr.New("SMTP server does not support STARTTLS; set security to tls or none")
+		}
+		if err := client.StartTLS(tlsConfig); err != nil {
+			return goerr.Wrap(err, "failed to start TLS")
+		}
+	}
+
+	if username := expandEnvVars(action.Username); username != "" {
+		auth := smtp.PlainAuth("", username, expandEnvVars(action.Password), action.Host)
+		if err := client.Auth(auth); err != nil {
+			return goerr.Wrap(err, "SMTP authentication failed")
+		}
+	}
+
+	if err := client.Mail(from.Address); err != nil {
+		return goerr.Wrap(err, "SMTP server rejected sender")
+	}
+	for _, rcpt := range recipients {
+		if err := client.Rcpt(rcpt); err != nil {
+			return goerr.Wrap(err, "SMTP server rejected recipient", goerr.V("recipient", rcpt))
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return goerr.Wrap(err, "failed to start message data")
+	}
+	if _, err := w.Write(data); err != nil {
+		return goerr.Wrap(err, "failed to write message")
+	}
+	if err := w.Close(); err != nil {
+		return goerr.Wrap(err, "SMTP server rejected message")
+	}
+
+	return client.Quit()
+}
+
+// encode formats the message as MIME. With both text and HTML bodies the
+// message is multipart/alternative so that clients pick the best one.
+func (m *emailMessage) encode(now time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+
+	writeHeader := func(key, value string) {
+		// Templates may produce line breaks; they must not start new headers
+		value = strings.NewReplacer("\r", " ", "\n", " ").Replace(value)
+		fmt.Fprintf(&buf, "%s: %s\r\n", key, value)
+	}
+
+	writeHeader("From", m.From)
+	writeHeader("To", strings.Join(m.To, ", "))
+	if len(m.Cc) > 0 {
+		writeHeader("Cc", strings.Join(m.Cc, ", "))
+	}
+	writeHeader("Subject", mime.QEncoding.Encode("utf-8", m.Subject))
+	writeHeader("Date", now.Format(time.RFC1123Z))
+	writeHeader("MIME-Version", "1.0")
+
+	switch {
+	case m.HTML == "":
+		return m.writeSinglePart(&buf, writeHeader, "text/plain", m.Text)
+	case m.Text == "":
+		return m.writeSinglePart(&buf, writeHeader, "text/html", m.HTML)
+	}
+
+	mw := multipart.NewWriter(&buf)
+	writeHeader("Content-Type", "multipart/alternative; boundary="+mw.Boundary())
+	buf.WriteString("\r\n")
+
+	for _, part := range []struct{ contentType, body string }{
+		{"text/plain", m.Text},
+		{"text/html", m.HTML},
+	} {
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Type", part.contentType+"; charset=utf-8")
+		header.Set("Content-Transfer-Encoding", "quoted-printable")
+		pw, err := mw.CreatePart(header)
+		if err != nil {
+			return nil, goerr.Wrap(err, "failed to create message part")
+		}
+		if err := writeQuotedPrintable(pw, part.body); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, goerr.Wrap(err, "failed to finish message")
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (m *emailMessage) writeSinglePart(buf *bytes.Buffer, writeHeader func(key, value string), contentType, body string) ([]byte, error) {
+	writeHeader("Content-Type", contentType+"; charset=utf-8")
+	writeHeader("Content-Transfer-Encoding", "quoted-printable")
+	buf.WriteString("\r\n")
+	if err := writeQuotedPrintable(buf, body); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeQuotedPrintable(w interface{ Write([]byte) (int, error) }, body string) error {
+	qw := quotedprintable.NewWriter(w)
+	if _, err := qw.Write([]byte(body)); err != nil {
+		return goerr.Wrap(err, "failed to encode message body")
+	}
+	if err := qw.Close(); err != nil {
+		return goerr.Wrap(err, "failed to encode message body")
+	}
+	return nil
+}
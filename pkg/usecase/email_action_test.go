@@ -0,0 +1,200 @@
+package usecase_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/m-mizutani/gt"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+	"github.com/m-mizutani/octap/pkg/usecase"
+)
+
+// smtpSink is a minimal SMTP server that records a single message
+type smtpSink struct {
+	addr       string
+	auth       chan string
+	recipients chan []string
+	data       chan string
+}
+
+func newSMTPSink(t *testing.T) *smtpSink {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	gt.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+
+	sink := &smtpSink{
+		addr:       ln.Addr().String(),
+		auth:       make(chan string, 1),
+		recipients: make(chan []string, 1),
+		data:       make(chan string, 1),
+	}
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		sink.serve(conn)
+	}()
+
+	return sink
+}
+
+func (s *smtpSink) serve(conn net.Conn) {
+	r := bufio.NewReader(conn)
+	reply := func(line string) { _, _ = io.WriteString(conn, line+"\r\n") }
+
+	var rcpts []string
+	reply("220 localhost ESMTP sink")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+
+		switch cmd {
+		case "EHLO":
+			reply("250-localhost")
+			reply("250 AUTH PLAIN")
+		case "AUTH":
+			fields := strings.Fields(line)
+			decoded, _ := base64.StdEncoding.DecodeString(fields[len(fields)-1])
+			s.auth <- string(decoded)
+			reply("235 authenticated")
+		case "MAIL":
+			reply("250 ok")
+		case "RCPT":
+			rcpts = append(rcpts, strings.Trim(strings.TrimPrefix(line, "RCPT TO:"), "<>"))
+			reply("250 ok")
+		case "DATA":
+			reply("354 go ahead")
+			var data strings.Builder
+			for {
+				dataLine, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				data.WriteString(dataLine)
+			}
+			s.recipients <- rcpts
+			s.data <- data.String()
+			reply("250 queued")
+		case "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("502 not implemented")
+		}
+	}
+}
+
+func mustAtoi(t *testing.T, s string) int {
+	t.Helper()
+	n, err := strconv.Atoi(s)
+	gt.NoError(t, err)
+	return n
+}
+
+func TestEmailAction(t *testing.T) {
+	sink := newSMTPSink(t)
+	host, port, err := net.SplitHostPort(sink.addr)
+	gt.NoError(t, err)
+
+	t.Setenv("OCTAP_TEST_SMTP_PASSWORD", "secret")
+
+	action := model.Action{
+		Type: "email",
+		Data: map[string]interface{}{
+			"host":     host,
+			"port":     mustAtoi(t, port),
+			"security": "none",
+			"username": "octap",
+			"password": "${OCTAP_TEST_SMTP_PASSWORD}",
+			"from":     "octap <octap@example.com>",
+			"to":       []interface{}{"dev@example.com"},
+			"cc":       []interface{}{"Lead <lead@example.com>"},
+			"subject":  "{{.Workflow}} failed ✗",
+			"body":     "See {{.RunURL}}",
+			"html":     `<a href="{{.RunURL}}">{{.Workflow}}</a>`,
+		},
+	}
+	event := model.WorkflowEvent{
+		Type:       model.HookCheckFailure,
+		Repository: "owner/repo",
+		Workflow:   "<build>",
+		URL:        "https://github.com/owner/repo/actions/runs/1",
+	}
+
+	gt.NoError(t, usecase.NewEmailAction().Execute(context.Background(), action, event)).Required()
+
+	gt.Equal(t, <-sink.auth, "\x00octap\x00secret")
+	gt.Equal(t, <-sink.recipients, []string{"dev@example.com", "lead@example.com"})
+
+	msg, err := mail.ReadMessage(strings.NewReader(<-sink.data))
+	gt.NoError(t, err)
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	gt.NoError(t, err)
+	gt.Equal(t, subject, "<build> failed ✗")
+	gt.Equal(t, msg.Header.Get("Cc"), "Lead <lead@example.com>")
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	gt.NoError(t, err)
+	gt.Equal(t, mediaType, "multipart/alternative")
+
+	parts := map[string]string{}
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		gt.NoError(t, err)
+		body, err := io.ReadAll(part)
+		gt.NoError(t, err)
+		contentType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		parts[contentType] = string(body)
+	}
+
+	gt.Equal(t, parts["text/plain"], "See "+event.URL)
+	// The HTML body is escaped by html/template
+	gt.Equal(t, parts["text/html"], `<a href="`+event.URL+`">&lt;build&gt;</a>`)
+}
+
+func TestEmailActionRequiresSTARTTLS(t *testing.T) {
+	sink := newSMTPSink(t)
+	host, port, err := net.SplitHostPort(sink.addr)
+	gt.NoError(t, err)
+
+	action := model.Action{
+		Type: "email",
+		Data: map[string]interface{}{
+			"host":    host,
+			"port":    mustAtoi(t, port),
+			"from":    "octap@example.com",
+			"to":      []interface{}{"dev@example.com"},
+			"subject": "done",
+			"body":    "done",
+		},
+	}
+
+	// The sink does not advertise STARTTLS, so the default security must refuse to send
+	err = usecase.NewEmailAction().Execute(context.Background(), action, model.WorkflowEvent{Type: model.HookCompleteSuccess})
+	gt.Error(t, err)
+}
@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/m-mizutani/ctxlog"
+	"github.com/m-mizutani/octap/pkg/domain/interfaces"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+)
+
+// estimateSampleSize is the number of recent successful runs used for an estimate
+const estimateSampleSize = 10
+
+// durationEstimator estimates how long workflow runs take from the durations of
+// recent successful runs of the same workflow. History is fetched once per workflow.
+type durationEstimator struct {
+	github    interfaces.GitHubService
+	repo      model.Repository
+	estimates map[int64]time.Duration
+}
+
+func newDurationEstimator(github interfaces.GitHubService, repo model.Repository) *durationEstimator {
+	return &durationEstimator{
+		github:    github,
+		repo:      repo,
+		estimates: make(map[int64]time.Duration),
+	}
+}
+
+// Apply sets EstimatedDuration on runs. Workflows without usable history are left without an estimate.
+func (e *durationEstimator) Apply(ctx context.Context, runs []*model.WorkflowRun) {
+	current := make(map[int64]bool, len(runs))
+	for _, run := range runs {
+		current[run.ID] = true
+	}
+
+	for _, run := range runs {
+		if run.WorkflowID == 0 {
+			continue
+		}
+
+		estimate, ok := e.estimates[run.WorkflowID]
+		if !ok {
+			estimate = e.fetch(ctx, run.WorkflowID, current)
+			e.estimates[run.WorkflowID] = estimate
+		}
+		run.EstimatedDuration = estimate
+	}
+}
+
+func (e *durationEstimator) fetch(ctx context.Context, workflowID int64, exclude map[int64]bool) time.Duration {
+	logger := ctxlog.From(ctx)
+
+	history, err := e.github.GetRecentWorkflowRuns(ctx, e.repo, workflowID, estimateSampleSize)
+	if err != nil {
+		// Estimates are best effort; don't retry on every poll
+		logger.Debug("failed to fetch workflow history for estimate",
+			slog.Int64("workflow_id", workflowID),
+			slog.String("error", err.Error()),
+		)
+		return 0
+	}
+
+	var durations []time.Duration
+	for _, run := range history {
+		if exclude[run.ID] || run.Conclusion != model.WorkflowConclusionSuccess {
+			continue
+		}
+		if d := run.UpdatedAt.Sub(run.CreatedAt); d > 0 {
+			durations = append(durations, d)
+		}
+	}
+
+	estimate := medianDuration(durations)
+	logger.Debug("estimated workflow duration",
+		slog.Int64("workflow_id", workflowID),
+		slog.Int("samples", len(durations)),
+		slog.Duration("estimate", estimate),
+	)
+	return estimate
+}
+
+func medianDuration(durations []time.Duration) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
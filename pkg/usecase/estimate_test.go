@@ -0,0 +1,65 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/m-mizutani/gt"
+	"github.com/m-mizutani/octap/pkg/domain/interfaces"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+	"github.com/m-mizutani/octap/pkg/usecase"
+)
+
+// fakeGitHubService returns canned workflow history
+type fakeGitHubService struct {
+	interfaces.GitHubService
+	history map[int64][]*model.WorkflowRun
+	calls   map[int64]int
+}
+
+func (f *fakeGitHubService) GetRecentWorkflowRuns(ctx context.Context, repo model.Repository, workflowID int64, limit int) ([]*model.WorkflowRun, error) {
+	if f.calls == nil {
+		f.calls = make(map[int64]int)
+	}
+	f.calls[workflowID]++
+	return f.history[workflowID], nil
+}
+
+func TestDurationEstimator(t *testing.T) {
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	success := func(id int64, d time.Duration) *model.WorkflowRun {
+		return &model.WorkflowRun{
+			ID:         id,
+			Status:     model.WorkflowStatusCompleted,
+			Conclusion: model.WorkflowConclusionSuccess,
+			CreatedAt:  base,
+			UpdatedAt:  base.Add(d),
+		}
+	}
+
+	github := &fakeGitHubService{
+		history: map[int64][]*model.WorkflowRun{
+			10: {success(1, 4*time.Minute), success(2, 6*time.Minute), success(3, 5*time.Minute), success(100, time.Hour)},
+		},
+	}
+	estimator := usecase.NewDurationEstimator(github, model.Repository{Owner: "owner", Name: "repo"})
+
+	runs := []*model.WorkflowRun{
+		// Run 100 is the run being monitored and must not be used as a sample
+		{ID: 100, WorkflowID: 10, Status: model.WorkflowStatusInProgress},
+		{ID: 101, WorkflowID: 20, Status: model.WorkflowStatusQueued},
+		{ID: 102, Status: model.WorkflowStatusQueued},
+	}
+	estimator.Apply(context.Background(), runs)
+	estimator.Apply(context.Background(), runs)
+
+	gt.Equal(t, runs[0].EstimatedDuration, 5*time.Minute)
+	gt.Equal(t, runs[1].EstimatedDuration, time.Duration(0))
+	gt.Equal(t, runs[2].EstimatedDuration, time.Duration(0))
+
+	// History is fetched only once per workflow
+	gt.Equal(t, github.calls[10], 1)
+	gt.Equal(t, github.calls[20], 1)
+	gt.Equal(t, len(github.calls), 2)
+}
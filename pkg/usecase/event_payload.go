@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/m-mizutani/octap/pkg/domain/model"
+)
+
+// eventPayload is the JSON representation of an event, sent by webhooks without
+// a body template and passed to commands
+type eventPayload struct {
+	EventType       string          `json:"event_type"`
+	Repository      string          `json:"repository,omitempty"`
+	Workflow        string          `json:"workflow,omitempty"`
+	RunID           int64           `json:"run_id,omitempty"`
+	RunURL          string          `json:"run_url,omitempty"`
+	Timestamp       time.Time       `json:"timestamp"`
+	DurationSeconds float64         `json:"duration_seconds"`
+	ETA             time.Time       `json:"eta,omitzero"`
+	Commit          model.Commit    `json:"commit,omitzero"`
+	Summary         *summaryPayload `json:"summary,omitempty"`
+	Runs            []runPayload    `json:"runs,omitempty"`
+	// Outputs are the outputs of earlier actions of the event, keyed by action ID
+	Outputs map[string]map[string]string `json:"outputs,omitempty"`
+	// Digest are the runs collapsed into a check event by the digest window
+	Digest []runPayload `json:"digest,omitempty"`
+}
+
+// runPayload is the JSON representation of a workflow run passed to webhooks and commands
+type runPayload struct {
+	RunID      int64  `json:"run_id"`
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion,omitempty"`
+	URL        string `json:"url"`
+}
+
+// summaryPayload is the JSON representation of the result counts of complete and aborted events
+type summaryPayload struct {
+	TotalRuns       int     `json:"total_runs"`
+	SuccessCount    int     `json:"success_count"`
+	FailureCount    int     `json:"failure_count"`
+	OtherCount      int     `json:"other_count"`
+	PendingCount    int     `json:"pending_count"`
+	DurationSeconds float64 `json:"duration_seconds"`
+}
+
+func newRunPayloads(runs []*model.WorkflowRun) []runPayload {
+	if len(runs) == 0 {
+		return nil
+	}
+
+	payloads := make([]runPayload, 0, len(runs))
+	for _, run := range runs {
+		payloads = append(payloads, runPayload{
+			RunID:      run.ID,
+			Name:       run.Name,
+			Status:     string(run.Status),
+			Conclusion: string(run.Conclusion),
+			URL:        run.URL,
+		})
+	}
+	return payloads
+}
+
+func newSummaryPayload(summary *model.Summary) *summaryPayload {
+	if summary == nil {
+		return nil
+	}
+
+	return &summaryPayload{
+		TotalRuns:       summary.TotalRuns,
+		SuccessCount:    summary.SuccessCount,
+		FailureCount:    summary.FailureCount,
+		OtherCount:      summary.OtherCount,
+		PendingCount:    summary.PendingCount,
+		DurationSeconds: summary.Duration.Round(time.Second).Seconds(),
+	}
+}
+
+func newEventPayload(event model.WorkflowEvent, now time.Time) eventPayload {
+	return eventPayload{
+		EventType:       string(event.Type),
+		Repository:      event.Repository,
+		Workflow:        event.Workflow,
+		RunID:           event.RunID,
+		RunURL:          event.URL,
+		Timestamp:       now,
+		DurationSeconds: event.Duration.Round(time.Second).Seconds(),
+		ETA:             event.ETA,
+		Commit:          event.Commit,
+		Summary:         newSummaryPayload(event.Summary),
+		Runs:            newRunPayloads(event.Runs),
+		Outputs:         event.Outputs,
+		Digest:          newRunPayloads(event.Digest),
+	}
+}
@@ -1,5 +1,12 @@
 package usecase
 
+import (
+	"context"
+
+	"github.com/m-mizutani/octap/pkg/domain/interfaces"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+)
+
 // Export for testing
 var ParseGitHubURL = parseGitHubURL
 
@@ -10,3 +17,67 @@ type ConfigService = configService
 func (c *configService) FindConfigInDirectory(dir string) string {
 	return c.findConfigInDirectory(dir)
 }
+
+// NewDurationEstimator exports durationEstimator for testing
+func NewDurationEstimator(github interfaces.GitHubService, repo model.Repository) interface {
+	Apply(ctx context.Context, runs []*model.WorkflowRun)
+} {
+	return newDurationEstimator(github, repo)
+}
+
+// DesktopNotification exports desktopNotification for testing
+type DesktopNotification = desktopNotification
+
+// BuildNotification exports notifyAction.buildNotification for testing
+func BuildNotification(action *model.NotifyAction, event model.WorkflowEvent) (*DesktopNotification, error) {
+	return (&notifyAction{}).buildNotification(action, event)
+}
+
+// NewPushoverActionWithEndpoint creates a pushover action that sends to endpoint instead of the Pushover API
+func NewPushoverActionWithEndpoint(endpoint string) interfaces.ActionExecutor {
+	action := NewPushoverAction().(*pushoverAction)
+	action.endpoint = endpoint
+	return action
+}
+
+// NewSlackActionWithAPIURL creates a slack action that calls the Web API at apiURL
+func NewSlackActionWithAPIURL(apiURL string) interfaces.ActionExecutor {
+	action := NewSlackAction().(*slackAction)
+	action.apiURL = apiURL
+	return action
+}
+
+// NewPolicyHookExecutorWithSlackAPI creates a policy hook executor whose slack actions call the Web API at apiURL
+func NewPolicyHookExecutorWithSlackAPI(config *model.Config, dir, apiURL string) interfaces.HookExecutor {
+	executor := NewPolicyHookExecutor(config, dir).(*policyHookExecutor)
+	executor.inner.actions["slack"] = NewSlackActionWithAPIURL(apiURL)
+	return executor
+}
+
+// RenderTemplate exports renderTemplate for testing
+var RenderTemplate = renderTemplate
+
+// RenderField exports renderField for testing
+var RenderField = renderField
+
+// EvaluateCondition exports evaluateCondition for testing
+var EvaluateCondition = evaluateCondition
+
+// CompileCondition exports compileCondition for testing
+var CompileCondition = compileCondition
+
+// WithRetry exports withRetry for testing
+var WithRetry = withRetry
+
+// Jitter exports jitter for testing
+var Jitter = jitter
+
+// NewHTTPStatusError creates the error HTTP actions return for a non-2xx status
+func NewHTTPStatusError(statusCode int) error {
+	return &httpStatusError{StatusCode: statusCode}
+}
+
+// NewPermanentError wraps err as an error that retrying cannot fix
+func NewPermanentError(err error) error {
+	return &permanentError{err}
+}
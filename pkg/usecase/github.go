@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"strings"
 
@@ -74,9 +75,9 @@ func (s *GitHubService) GetCurrentCommit(ctx context.Context, repoPath string) (
 
 	if !found {
 		logger.Warn("Commit not found in remote branches",
-			slog.String("sha", commitSHA[:8]),
+			slog.String("sha", model.ShortSHA(commitSHA)),
 		)
-		return "", goerr.Wrap(domain.ErrNotPushed, "commit not found in remote", goerr.V("commit", commitSHA[:8]))
+		return "", goerr.Wrap(domain.ErrNotPushed, "commit not found in remote", goerr.V("commit", model.ShortSHA(commitSHA)))
 	}
 
 	return commitSHA, nil
@@ -180,20 +181,7 @@ func (s *GitHubService) GetWorkflowRuns(ctx context.Context, repo model.Reposito
 
 	var workflowRuns []*model.WorkflowRun
 	for _, run := range runs.WorkflowRuns {
-		workflowRun := &model.WorkflowRun{
-			ID:        run.GetID(),
-			Name:      run.GetName(),
-			Status:    convertStatus(run.GetStatus()),
-			URL:       run.GetHTMLURL(),
-			CreatedAt: run.GetCreatedAt().Time,
-			UpdatedAt: run.GetUpdatedAt().Time,
-		}
-
-		if run.GetStatus() == "completed" {
-			workflowRun.Conclusion = convertConclusion(run.GetConclusion())
-		}
-
-		workflowRuns = append(workflowRuns, workflowRun)
+		workflowRuns = append(workflowRuns, convertWorkflowRun(run))
 	}
 
 	logger.Debug("fetched workflow runs",
@@ -205,6 +193,209 @@ func (s *GitHubService) GetWorkflowRuns(ctx context.Context, repo model.Reposito
 	return workflowRuns, nil
 }
 
+// GetRecentWorkflowRuns returns up to limit most recent successful runs of the workflow
+func (s *GitHubService) GetRecentWorkflowRuns(ctx context.Context, repo model.Repository, workflowID int64, limit int) ([]*model.WorkflowRun, error) {
+	client, err := s.authService.GetAuthenticatedClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &github.ListWorkflowRunsOptions{
+		Status: "success",
+		ListOptions: github.ListOptions{
+			PerPage: limit,
+		},
+	}
+
+	runs, _, err := client.Actions.ListWorkflowRunsByID(ctx, repo.Owner, repo.Name, workflowID, opts)
+	if err != nil {
+		return nil, domain.ErrAPIRequest.Wrap(err)
+	}
+
+	var workflowRuns []*model.WorkflowRun
+	for _, run := range runs.WorkflowRuns {
+		workflowRuns = append(workflowRuns, convertWorkflowRun(run))
+	}
+
+	return workflowRuns, nil
+}
+
+// ListWorkflowRuns returns recent runs of the repository matching the filter, newest first
+func (s *GitHubService) ListWorkflowRuns(ctx context.Context, repo model.Repository, filter model.RunFilter) ([]*model.WorkflowRun, error) {
+	client, err := s.authService.GetAuthenticatedClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+
+	opts := &github.ListWorkflowRunsOptions{
+		Branch: filter.Branch,
+		ListOptions: github.ListOptions{
+			PerPage: min(limit, 100),
+		},
+	}
+
+	var workflowRuns []*model.WorkflowRun
+	for len(workflowRuns) < limit {
+		var runs *github.WorkflowRuns
+		var resp *github.Response
+		if filter.WorkflowID != 0 {
+			runs, resp, err = client.Actions.ListWorkflowRunsByID(ctx, repo.Owner, repo.Name, filter.WorkflowID, opts)
+		} else {
+			runs, resp, err = client.Actions.ListRepositoryWorkflowRuns(ctx, repo.Owner, repo.Name, opts)
+		}
+		if err != nil {
+			return nil, domain.ErrAPIRequest.Wrap(err)
+		}
+
+		for _, run := range runs.WorkflowRuns {
+			if len(workflowRuns) >= limit {
+				break
+			}
+			workflowRuns = append(workflowRuns, convertWorkflowRun(run))
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return workflowRuns, nil
+}
+
+// GetWorkflowRun returns the latest attempt of a workflow run
+func (s *GitHubService) GetWorkflowRun(ctx context.Context, repo model.Repository, runID int64) (*model.WorkflowRun, error) {
+	client, err := s.authService.GetAuthenticatedClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	run, _, err := client.Actions.GetWorkflowRunByID(ctx, repo.Owner, repo.Name, runID)
+	if err != nil {
+		return nil, domain.ErrAPIRequest.Wrap(err)
+	}
+
+	return convertWorkflowRun(run), nil
+}
+
+// GetWorkflowRunAttempt returns a specific attempt of a workflow run
+func (s *GitHubService) GetWorkflowRunAttempt(ctx context.Context, repo model.Repository, runID int64, attempt int) (*model.WorkflowRun, error) {
+	client, err := s.authService.GetAuthenticatedClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	run, _, err := client.Actions.GetWorkflowRunAttempt(ctx, repo.Owner, repo.Name, runID, attempt, nil)
+	if err != nil {
+		return nil, domain.ErrAPIRequest.Wrap(err)
+	}
+
+	return convertWorkflowRun(run), nil
+}
+
+func (s *GitHubService) GetWorkflowJobs(ctx context.Context, repo model.Repository, runID int64) ([]*model.WorkflowJob, error) {
+	client, err := s.authService.GetAuthenticatedClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &github.ListWorkflowJobsOptions{
+		Filter: "latest",
+		ListOptions: github.ListOptions{
+			PerPage: 100,
+		},
+	}
+
+	jobs, _, err := client.Actions.ListWorkflowJobs(ctx, repo.Owner, repo.Name, runID, opts)
+	if err != nil {
+		return nil, domain.ErrAPIRequest.Wrap(err)
+	}
+
+	var workflowJobs []*model.WorkflowJob
+	for _, job := range jobs.Jobs {
+		workflowJob := &model.WorkflowJob{
+			ID:          job.GetID(),
+			RunID:       job.GetRunID(),
+			Name:        job.GetName(),
+			Status:      convertStatus(job.GetStatus()),
+			URL:         job.GetHTMLURL(),
+			StartedAt:   job.GetStartedAt().Time,
+			CompletedAt: job.GetCompletedAt().Time,
+		}
+
+		if job.GetStatus() == "completed" {
+			workflowJob.Conclusion = convertConclusion(job.GetConclusion())
+		}
+
+		workflowJobs = append(workflowJobs, workflowJob)
+	}
+
+	return workflowJobs, nil
+}
+
+func (s *GitHubService) RerunWorkflowRun(ctx context.Context, repo model.Repository, runID int64) error {
+	client, err := s.authService.GetAuthenticatedClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.Actions.RerunWorkflowByID(ctx, repo.Owner, repo.Name, runID); err != nil {
+		return domain.ErrAPIRequest.Wrap(err)
+	}
+	return nil
+}
+
+func (s *GitHubService) CancelWorkflowRun(ctx context.Context, repo model.Repository, runID int64) error {
+	client, err := s.authService.GetAuthenticatedClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.Actions.CancelWorkflowRunByID(ctx, repo.Owner, repo.Name, runID); err != nil {
+		// Cancellation is asynchronous and GitHub answers 202 Accepted
+		var accepted *github.AcceptedError
+		if errors.As(err, &accepted) {
+			return nil
+		}
+		return domain.ErrAPIRequest.Wrap(err)
+	}
+	return nil
+}
+
+func convertWorkflowRun(run *github.WorkflowRun) *model.WorkflowRun {
+	workflowRun := &model.WorkflowRun{
+		ID:            run.GetID(),
+		WorkflowID:    run.GetWorkflowID(),
+		Name:          run.GetName(),
+		Repository:    run.GetRepository().GetFullName(),
+		Branch:        run.GetHeadBranch(),
+		HeadSHA:       run.GetHeadSHA(),
+		CommitMessage: run.GetHeadCommit().GetMessage(),
+		CommitAuthor:  run.GetHeadCommit().GetAuthor().GetName(),
+		Actor:         run.GetTriggeringActor().GetLogin(),
+		RunAttempt:    run.GetRunAttempt(),
+		Status:        convertStatus(run.GetStatus()),
+		URL:           run.GetHTMLURL(),
+		CreatedAt:     run.GetCreatedAt().Time,
+		UpdatedAt:     run.GetUpdatedAt().Time,
+		StartedAt:     run.GetRunStartedAt().Time,
+	}
+
+	if workflowRun.Actor == "" {
+		workflowRun.Actor = run.GetActor().GetLogin()
+	}
+	if run.GetStatus() == "completed" {
+		workflowRun.Conclusion = convertConclusion(run.GetConclusion())
+	}
+
+	return workflowRun
+}
+
 func convertStatus(status string) model.WorkflowStatus {
 	switch status {
 	case "queued":
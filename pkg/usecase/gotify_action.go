@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/m-mizutani/ctxlog"
+	"github.com/m-mizutani/goerr/v2"
+	"github.com/m-mizutani/octap/pkg/domain/interfaces"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+)
+
+// gotifyPriorities maps push priorities to Gotify's 0 to 10 scale. Android
+// clients stay silent below 4 and pop up from 8.
+var gotifyPriorities = map[model.PushPriority]int{
+	model.PushPriorityMin:     0,
+	model.PushPriorityLow:     2,
+	model.PushPriorityDefault: 5,
+	model.PushPriorityHigh:    8,
+	model.PushPriorityUrgent:  10,
+}
+
+type gotifyAction struct {
+	httpClient *http.Client
+}
+
+// NewGotifyAction creates a new GotifyAction instance
+func NewGotifyAction() interfaces.ActionExecutor {
+	return &gotifyAction{
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// Execute sends a message to a Gotify server
+func (g *gotifyAction) Execute(ctx context.Context, action model.Action, event model.WorkflowEvent) error {
+	logger := ctxlog.From(ctx)
+
+	logger.Debug("gotifyAction.Execute called",
+		slog.String("event_type", string(event.Type)),
+	)
+
+	gotifyAction, err := action.ToGotifyAction()
+	if err != nil {
+		logger.Error("Failed to parse gotify action",
+			slog.String("error", err.Error()),
+		)
+		return goerr.Wrap(err, "failed to parse gotify action")
+	}
+
+	token := expandEnvVars(gotifyAction.Token)
+	if token == "" {
+		return goerr.New("gotify token is empty after expansion")
+	}
+
+	payload, err := g.buildPayload(gotifyAction, event)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return goerr.Wrap(err, "failed to marshal gotify payload")
+	}
+
+	server, err := renderField("server", gotifyAction.Server, event)
+	if err != nil {
+		return goerr.Wrap(err, "failed to build gotify server URL")
+	}
+	server = strings.TrimSuffix(server, "/")
+	headers := make(http.Header)
+	headers.Set("Content-Type", "application/json")
+	headers.Set("X-Gotify-Key", token)
+
+	err = sendRequest(ctx, g.httpClient, http.MethodPost, server+"/message", headers, body)
+	if err != nil {
+		return goerr.Wrap(err, "failed to send gotify notification", goerr.V("server", server))
+	}
+
+	logger.Debug("Gotify notification sent successfully")
+	return nil
+}
+
+// buildPayload renders the templates into a Gotify message
+func (g *gotifyAction) buildPayload(action *model.GotifyAction, event model.WorkflowEvent) (*model.GotifyPayload, error) {
+	title, message, err := renderPushMessage(action.Title, action.Message, event)
+	if err != nil {
+		return nil, goerr.Wrap(err, "failed to build gotify message")
+	}
+
+	payload := &model.GotifyPayload{
+		Title:    title,
+		Message:  message,
+		Priority: gotifyPriorities[pushPriority(action.Priority, event.Type)],
+	}
+	if event.URL != "" {
+		payload.Extras = map[string]any{
+			"client::notification": map[string]any{
+				"click": map[string]any{"url": event.URL},
+			},
+		}
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,49 @@
+package usecase_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/m-mizutani/gt"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+	"github.com/m-mizutani/octap/pkg/usecase"
+)
+
+func TestGotifyAction(t *testing.T) {
+	var received model.GotifyPayload
+	var path, key string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		key = r.Header.Get("X-Gotify-Key")
+		gt.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+
+	action := model.Action{
+		Type: "gotify",
+		Data: map[string]interface{}{
+			"server":  server.URL + "/",
+			"token":   "app-token",
+			"message": "{{.Workflow}} passed",
+		},
+	}
+	event := model.WorkflowEvent{
+		Type:     model.HookCheckSuccess,
+		Workflow: "build",
+		URL:      "https://github.com/owner/repo/actions/runs/1",
+	}
+
+	gt.NoError(t, usecase.NewGotifyAction().Execute(context.Background(), action, event)).Required()
+	gt.Equal(t, path, "/message")
+	gt.Equal(t, key, "app-token")
+	gt.Equal(t, received.Title, "octap")
+	gt.Equal(t, received.Message, "build passed")
+	gt.Equal(t, received.Priority, 2)
+
+	notification, ok := received.Extras["client::notification"].(map[string]any)
+	gt.True(t, ok)
+	gt.Equal(t, notification["click"], any(map[string]any{"url": event.URL}))
+}
@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/m-mizutani/ctxlog"
+	"github.com/m-mizutani/goerr/v2"
+	"github.com/m-mizutani/octap/pkg/domain/interfaces"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+)
+
+// historyFileName is the JSON Lines file holding one session per line
+const historyFileName = "history.jsonl"
+
+type historyStore struct {
+	path string
+	// err is why the store is not available, such as an unknown home directory
+	err error
+}
+
+// NewHistoryStore creates a HistoryStore that keeps sessions in dir.
+// An empty dir selects the default ~/.config/octap directory.
+func NewHistoryStore(dir string) interfaces.HistoryStore {
+	path, err := dataPath(dir, historyFileName)
+	if err != nil {
+		return &historyStore{err: goerr.Wrap(err, "history store is not available")}
+	}
+	return &historyStore{path: path}
+}
+
+// Save appends the session to the history file
+func (h *historyStore) Save(ctx context.Context, session *model.Session) error {
+	if h.err != nil {
+		return h.err
+	}
+	if err := os.MkdirAll(filepath.Dir(h.path), 0700); err != nil {
+		return goerr.Wrap(err, "failed to create history directory")
+	}
+
+	data, err := json.Marshal(session)
+	if err != nil {
+		return goerr.Wrap(err, "failed to marshal session")
+	}
+
+	f, err := os.OpenFile(h.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600) // #nosec G304 - path is constructed from a fixed directory path
+	if err != nil {
+		return goerr.Wrap(err, "failed to open history file")
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return goerr.Wrap(err, "failed to write history", goerr.V("path", h.path))
+	}
+	return nil
+}
+
+// List reads the history file and returns matching sessions, newest first
+func (h *historyStore) List(ctx context.Context, filter model.HistoryFilter) ([]*model.Session, error) {
+	logger := ctxlog.From(ctx)
+	if h.err != nil {
+		return nil, h.err
+	}
+
+	f, err := os.Open(h.path) // #nosec G304 - path is constructed from a fixed directory path
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, goerr.Wrap(err, "failed to open history file")
+	}
+	defer f.Close()
+
+	var sessions []*model.Session
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		var session model.Session
+		if err := json.Unmarshal(scanner.Bytes(), &session); err != nil {
+			// A partially written line must not make the whole history unreadable
+			logger.Warn("skipping broken history entry",
+				slog.String("path", h.path),
+				slog.Int("line", lineNo),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		if matchSession(&session, filter) {
+			sessions = append(sessions, &session)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, goerr.Wrap(err, "failed to read history file")
+	}
+
+	// Sessions are appended in completion order; return newest first
+	for i, j := 0, len(sessions)-1; i < j; i, j = i+1, j-1 {
+		sessions[i], sessions[j] = sessions[j], sessions[i]
+	}
+	if filter.Limit > 0 && len(sessions) > filter.Limit {
+		sessions = sessions[:filter.Limit]
+	}
+
+	return sessions, nil
+}
+
+func matchSession(session *model.Session, filter model.HistoryFilter) bool {
+	if filter.Repository != "" && session.Repository != filter.Repository {
+		return false
+	}
+	if filter.Branch != "" && session.Branch != filter.Branch {
+		return false
+	}
+	if !filter.Since.IsZero() && session.FinishedAt.Before(filter.Since) {
+		return false
+	}
+
+	if filter.Workflow != "" {
+		run := session.FindRun(filter.Workflow)
+		if run == nil {
+			return false
+		}
+		if filter.Conclusion != "" && string(run.Conclusion) != filter.Conclusion {
+			return false
+		}
+		return true
+	}
+
+	if filter.Conclusion != "" && string(session.Conclusion) != filter.Conclusion {
+		return false
+	}
+	return true
+}
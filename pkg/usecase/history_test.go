@@ -0,0 +1,139 @@
+package usecase_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/m-mizutani/gt"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+	"github.com/m-mizutani/octap/pkg/usecase"
+)
+
+func TestHistoryStore(t *testing.T) {
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	session := func(sha, branch string, finished time.Time, conclusion model.SessionConclusion, runs ...*model.WorkflowRun) *model.Session {
+		return &model.Session{
+			Repository: "owner/repo",
+			CommitSHA:  sha,
+			Branch:     branch,
+			StartedAt:  finished.Add(-5 * time.Minute),
+			FinishedAt: finished,
+			Conclusion: conclusion,
+			Runs:       runs,
+		}
+	}
+	run := func(name string, conclusion model.WorkflowConclusion) *model.WorkflowRun {
+		return &model.WorkflowRun{Name: name, Status: model.WorkflowStatusCompleted, Conclusion: conclusion}
+	}
+
+	t.Run("List returns nothing when history does not exist", func(t *testing.T) {
+		store := usecase.NewHistoryStore(t.TempDir())
+		sessions, err := store.List(context.Background(), model.HistoryFilter{})
+		gt.NoError(t, err)
+		gt.A(t, sessions).Length(0)
+	})
+
+	t.Run("Save and List with filters", func(t *testing.T) {
+		dir := t.TempDir()
+		store := usecase.NewHistoryStore(dir)
+		ctx := context.Background()
+
+		gt.NoError(t, store.Save(ctx, session("sha1", "main", base, model.SessionConclusionSuccess,
+			run("build", model.WorkflowConclusionSuccess), run("test", model.WorkflowConclusionSuccess))))
+		gt.NoError(t, store.Save(ctx, session("sha2", "main", base.Add(time.Hour), model.SessionConclusionFailure,
+			run("build", model.WorkflowConclusionSuccess), run("test", model.WorkflowConclusionFailure))))
+		gt.NoError(t, store.Save(ctx, session("sha3", "feature", base.Add(2*time.Hour), model.SessionConclusionSuccess,
+			run("build", model.WorkflowConclusionSuccess))))
+
+		all, err := store.List(ctx, model.HistoryFilter{})
+		gt.NoError(t, err)
+		gt.A(t, all).Length(3)
+		gt.Equal(t, all[0].CommitSHA, "sha3") // newest first
+
+		// When did test last pass on main?
+		passed, err := store.List(ctx, model.HistoryFilter{
+			Branch:     "main",
+			Workflow:   "test",
+			Conclusion: "success",
+			Limit:      1,
+		})
+		gt.NoError(t, err)
+		gt.A(t, passed).Length(1)
+		gt.Equal(t, passed[0].CommitSHA, "sha1")
+
+		failed, err := store.List(ctx, model.HistoryFilter{Conclusion: "failure"})
+		gt.NoError(t, err)
+		gt.A(t, failed).Length(1)
+		gt.Equal(t, failed[0].CommitSHA, "sha2")
+
+		recent, err := store.List(ctx, model.HistoryFilter{Since: base.Add(90 * time.Minute)})
+		gt.NoError(t, err)
+		gt.A(t, recent).Length(1)
+
+		limited, err := store.List(ctx, model.HistoryFilter{Limit: 2})
+		gt.NoError(t, err)
+		gt.A(t, limited).Length(2)
+	})
+
+	t.Run("List skips broken entries", func(t *testing.T) {
+		dir := t.TempDir()
+		store := usecase.NewHistoryStore(dir)
+		ctx := context.Background()
+
+		gt.NoError(t, store.Save(ctx, session("sha1", "main", base, model.SessionConclusionSuccess)))
+		f, err := os.OpenFile(filepath.Join(dir, "history.jsonl"), os.O_APPEND|os.O_WRONLY, 0600)
+		gt.NoError(t, err)
+		_, err = f.WriteString("{\"repository\": \"trunc\n")
+		gt.NoError(t, err)
+		gt.NoError(t, f.Close())
+		gt.NoError(t, store.Save(ctx, session("sha2", "main", base, model.SessionConclusionSuccess)))
+
+		sessions, err := store.List(ctx, model.HistoryFilter{})
+		gt.NoError(t, err)
+		gt.A(t, sessions).Length(2)
+	})
+}
+
+func TestStoresWithoutHomeDirectory(t *testing.T) {
+	if runtime.GOOS != "linux" && runtime.GOOS != "darwin" {
+		t.Skip("unsets HOME to hide the home directory")
+	}
+	t.Setenv("HOME", "")
+	// Nothing must be written relative to the working directory
+	cwd := t.TempDir()
+	t.Chdir(cwd)
+	ctx := context.Background()
+
+	t.Run("history store fails", func(t *testing.T) {
+		store := usecase.NewHistoryStore("")
+		gt.Error(t, store.Save(ctx, &model.Session{Repository: "owner/repo", CommitSHA: "abc1234"}))
+		_, err := store.List(ctx, model.HistoryFilter{})
+		gt.Error(t, err)
+	})
+
+	t.Run("state store is turned off", func(t *testing.T) {
+		store := usecase.NewStateStore("")
+		gt.NoError(t, store.Save(ctx, &model.MonitorState{Repository: "owner/repo", CommitSHA: "abc1234"}))
+		state, err := store.Load(ctx, "owner/repo", "abc1234")
+		gt.NoError(t, err)
+		gt.Nil(t, state)
+	})
+
+	t.Run("dedup fires without locks", func(t *testing.T) {
+		executor := usecase.NewPolicyHookExecutor(&model.Config{Hooks: model.HooksConfig{
+			Policy:       model.NotificationPolicy{Dedup: true},
+			CheckFailure: []model.Action{noopAction},
+		}}, "")
+		gt.NoError(t, executor.Execute(ctx, checkEvent(model.HookCheckFailure, 1, "CI")))
+		executor.WaitForCompletion()
+		gt.A(t, executor.Results()).Length(1)
+	})
+
+	entries, err := os.ReadDir(cwd)
+	gt.NoError(t, err)
+	gt.A(t, entries).Length(0)
+}
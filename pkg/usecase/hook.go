@@ -1,11 +1,16 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"log/slog"
+	"slices"
 	"sync"
+	"time"
 
 	"github.com/m-mizutani/ctxlog"
+	"github.com/m-mizutani/goerr/v2"
 	"github.com/m-mizutani/octap/pkg/domain/interfaces"
 	"github.com/m-mizutani/octap/pkg/domain/model"
 )
@@ -14,17 +19,38 @@ type hookExecutor struct {
 	config       *model.Config
 	actions      map[string]interfaces.ActionExecutor
 	allActionsWg sync.WaitGroup // Tracks all actions across all events
+
+	mu      sync.Mutex
+	results model.HookResults
+
+	// policy suppresses actions by quiet hours and rate limits; nil runs every action
+	policy *actionPolicy
+}
+
+// actionFactories create the executor of each action type
+var actionFactories = map[string]func() interfaces.ActionExecutor{
+	"sound":    NewSoundAction,
+	"slack":    NewSlackAction,
+	"command":  NewCommandAction,
+	"notify":   NewNotifyAction,
+	"webhook":  NewWebhookAction,
+	"discord":  NewDiscordAction,
+	"teams":    NewTeamsAction,
+	"email":    NewEmailAction,
+	"ntfy":     NewNtfyAction,
+	"gotify":   NewGotifyAction,
+	"pushover": NewPushoverAction,
 }
 
 // NewHookExecutor creates a new HookExecutor instance
 func NewHookExecutor(config *model.Config) interfaces.HookExecutor {
+	actions := make(map[string]interfaces.ActionExecutor, len(actionFactories))
+	for actionType, newAction := range actionFactories {
+		actions[actionType] = newAction()
+	}
 	return &hookExecutor{
-		config: config,
-		actions: map[string]interfaces.ActionExecutor{
-			"sound":   NewSoundAction(),
-			"slack":   NewSlackAction(),
-			"command": NewCommandAction(),
-		},
+		config:  config,
+		actions: actions,
 	}
 }
 
@@ -44,12 +70,20 @@ func (h *hookExecutor) Execute(ctx context.Context, event model.WorkflowEvent) e
 		slog.Int("action_count", len(actions)),
 	)
 
+	sequential := h.config != nil && h.config.Hooks.Sequential
+	deps, err := actionDependencies(actions, sequential)
+	if err != nil {
+		return goerr.Wrap(err, "invalid hook pipeline", goerr.V("event", event.Type))
+	}
+
 	// Use WaitGroup to ensure all hooks complete
 	var wg sync.WaitGroup
 
-	// For complete events, we need to wait for all actions to finish
-	shouldWait := event.Type == model.HookCompleteSuccess || event.Type == model.HookCompleteFailure
+	// For complete and aborted events, we need to wait for all actions to finish
+	shouldWait := event.Type.IsSummary()
 
+	// Actions without dependencies start at once; the others wait for the actions they need
+	p := newPipeline(len(actions))
 	for i, action := range actions {
 		logger.Debug("Executing action",
 			slog.Int("index", i),
@@ -65,25 +99,17 @@ func (h *hookExecutor) Execute(ctx context.Context, event model.WorkflowEvent) e
 			defer wg.Done()
 			defer h.allActionsWg.Done() // Mark as done globally
 
-			logger.Debug("Starting action execution",
-				slog.Int("index", idx),
-				slog.String("type", a.Type),
-			)
-
-			if err := h.executeAction(ctx, a, event); err != nil {
-				logger.Warn("Failed to execute hook action",
+			if !p.wait(deps[idx]) {
+				logger.Debug("Skipping action because an action it needs failed",
 					slog.Int("index", idx),
 					slog.String("type", a.Type),
-					slog.String("event", string(event.Type)),
-					slog.String("error", err.Error()),
-				)
-			} else {
-				logger.Debug("Hook action executed successfully",
-					slog.Int("index", idx),
-					slog.String("type", a.Type),
-					slog.String("event", string(event.Type)),
 				)
+				h.record(newHookResult(event, a, idx, model.HookStatusSkipped))
+				p.finish(idx, true)
+				return
 			}
+
+			p.finish(idx, !h.runAction(ctx, a, idx, event, p))
 		}(action, i)
 	}
 
@@ -104,6 +130,129 @@ func (h *hookExecutor) Execute(ctx context.Context, event model.WorkflowEvent) e
 	return nil
 }
 
+// runAction evaluates the condition of an action and executes it, and reports
+// whether actions that need it may run
+func (h *hookExecutor) runAction(ctx context.Context, action model.Action, idx int, event model.WorkflowEvent, p *pipeline) bool {
+	logger := ctxlog.From(ctx)
+	event.Outputs = p.snapshot()
+	result := newHookResult(event, action, idx, model.HookStatusSuccess)
+	start := time.Now()
+
+	// In a dry run each action writes to its own buffer, printed in one piece when it finishes
+	var dryRun *bytes.Buffer
+	if out := dryRunOutput(ctx); out != nil {
+		dryRun = new(bytes.Buffer)
+		ctx = withDryRunBuffer(ctx, dryRun)
+		defer func() {
+			_, _ = out.Write(append([]byte(dryRunHeader(result)), dryRun.Bytes()...))
+		}()
+	}
+	defer func() {
+		result.Duration = time.Since(start)
+		h.record(result)
+	}()
+
+	matched, err := evaluateCondition(action.When, event, time.Now())
+	if err != nil {
+		logger.Warn("Failed to evaluate hook condition",
+			slog.Int("index", idx),
+			slog.String("type", action.Type),
+			slog.String("event", string(event.Type)),
+			slog.String("error", err.Error()),
+		)
+		result.Status = model.HookStatusFailed
+		result.Error = err.Error()
+		return action.ContinueOnError
+	}
+	if !matched {
+		// A skipped action does not stop the actions that need it
+		logger.Debug("Skipping action whose condition is not met",
+			slog.Int("index", idx),
+			slog.String("type", action.Type),
+			slog.String("when", action.When),
+		)
+		result.Status = model.HookStatusSkipped
+		return true
+	}
+	if reason := h.policy.suppress(action, idx, event); reason != "" {
+		logger.Debug("Skipping action suppressed by the notification policy",
+			slog.Int("index", idx),
+			slog.String("type", action.Type),
+			slog.String("event", string(event.Type)),
+			slog.String("reason", reason),
+		)
+		result.Status = model.HookStatusSkipped
+		return true
+	}
+
+	logger.Debug("Starting action execution",
+		slog.Int("index", idx),
+		slog.String("type", action.Type),
+	)
+
+	ctx, retries := withRetryCounter(ctx)
+	outputs, err := h.executeWithRetry(ctx, action, event)
+	result.Retries = int(retries.Load())
+	if err != nil {
+		logger.Warn("Failed to execute hook action",
+			slog.Int("index", idx),
+			slog.String("type", action.Type),
+			slog.String("event", string(event.Type)),
+			slog.String("error", err.Error()),
+		)
+		result.Status = model.HookStatusFailed
+		result.Error = err.Error()
+		return action.ContinueOnError
+	}
+
+	logger.Debug("Hook action executed successfully",
+		slog.Int("index", idx),
+		slog.String("type", action.Type),
+		slog.String("event", string(event.Type)),
+	)
+	if action.ID != "" && outputs != nil {
+		p.setOutputs(action.ID, outputs)
+	}
+	return true
+}
+
+func newHookResult(event model.WorkflowEvent, action model.Action, idx int, status model.HookStatus) model.HookResult {
+	return model.HookResult{
+		Event:    event.Type,
+		Workflow: event.Workflow,
+		Index:    idx,
+		ID:       action.ID,
+		Type:     action.Type,
+		Status:   status,
+	}
+}
+
+// dryRunHeader introduces the dry run output of an action, e.g. "==> [0] slack on check_failure: success"
+func dryRunHeader(result model.HookResult) string {
+	name := result.Type
+	if result.ID != "" {
+		name += " (" + result.ID + ")"
+	}
+	header := fmt.Sprintf("==> [%d] %s on %s: %s", result.Index, name, result.Event, result.Status)
+	if result.Error != "" {
+		header += ": " + result.Error
+	}
+	return header + "\n"
+}
+
+func (h *hookExecutor) record(result model.HookResult) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.results = append(h.results, result)
+}
+
+// Results returns the outcome of every action finished so far
+func (h *hookExecutor) Results() model.HookResults {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return slices.Clone(h.results)
+}
+
 // getActionsForEvent returns actions configured for the given event type
 func (h *hookExecutor) getActionsForEvent(eventType model.HookEvent) []model.Action {
 	if h.config == nil {
@@ -112,40 +261,21 @@ func (h *hookExecutor) getActionsForEvent(eventType model.HookEvent) []model.Act
 		return nil
 	}
 
-	logger := ctxlog.From(context.Background())
-	var actions []model.Action
-
-	switch eventType {
-	case model.HookCheckSuccess:
-		actions = h.config.Hooks.CheckSuccess
-		logger.Debug("Getting CheckSuccess actions", slog.Int("count", len(actions)))
-	case model.HookCheckFailure:
-		actions = h.config.Hooks.CheckFailure
-		logger.Debug("Getting CheckFailure actions", slog.Int("count", len(actions)))
-	case model.HookCompleteSuccess:
-		actions = h.config.Hooks.CompleteSuccess
-		logger.Debug("Getting CompleteSuccess actions", slog.Int("count", len(actions)))
-	case model.HookCompleteFailure:
-		actions = h.config.Hooks.CompleteFailure
-		logger.Debug("Getting CompleteFailure actions", slog.Int("count", len(actions)))
-	default:
-		logger.Debug("Unknown event type", slog.String("event_type", string(eventType)))
-		return nil
-	}
-
+	actions := h.config.Hooks.Actions(eventType)
+	ctxlog.From(context.Background()).Debug("Getting actions for event",
+		slog.String("event_type", string(eventType)),
+		slog.Int("count", len(actions)),
+	)
 	return actions
 }
 
-// executeAction executes a single action
-func (h *hookExecutor) executeAction(ctx context.Context, action model.Action, event model.WorkflowEvent) error {
+// executeAction executes a single action and returns its outputs, if it produces any
+func (h *hookExecutor) executeAction(ctx context.Context, action model.Action, event model.WorkflowEvent) (map[string]string, error) {
 	logger := ctxlog.From(ctx)
 
 	executor, ok := h.actions[action.Type]
 	if !ok {
-		logger.Warn("Unknown action type",
-			slog.String("type", action.Type),
-		)
-		return nil
+		return nil, goerr.New("unknown action type", goerr.V("type", action.Type))
 	}
 
 	logger.Debug("Calling action executor",
@@ -153,7 +283,13 @@ func (h *hookExecutor) executeAction(ctx context.Context, action model.Action, e
 		slog.Any("action_data", action.Data),
 	)
 
-	err := executor.Execute(ctx, action, event)
+	var outputs map[string]string
+	var err error
+	if outputExecutor, ok := executor.(interfaces.OutputActionExecutor); ok {
+		outputs, err = outputExecutor.ExecuteWithOutputs(ctx, action, event)
+	} else {
+		err = executor.Execute(ctx, action, event)
+	}
 	if err != nil {
 		logger.Debug("Action executor returned error",
 			slog.String("action_type", action.Type),
@@ -164,7 +300,31 @@ func (h *hookExecutor) executeAction(ctx context.Context, action model.Action, e
 			slog.String("action_type", action.Type),
 		)
 	}
-	return err
+	return outputs, err
+}
+
+// executeWithRetry executes an action under its retry policy and timeout
+func (h *hookExecutor) executeWithRetry(ctx context.Context, action model.Action, event model.WorkflowEvent) (map[string]string, error) {
+	policy, err := actionRetryPolicy(action)
+	if err != nil {
+		return nil, goerr.Wrap(err, "invalid retry policy")
+	}
+	timeout, err := actionTimeout(action)
+	if err != nil {
+		return nil, goerr.Wrap(err, "invalid timeout")
+	}
+	// A dry run prints what the action would send once
+	if dryRunOutput(ctx) != nil {
+		policy.Attempts = 1
+	}
+
+	var outputs map[string]string
+	err = withRetry(ctx, policy, timeout, func(ctx context.Context) error {
+		var err error
+		outputs, err = h.executeAction(ctx, action, event)
+		return err
+	})
+	return outputs, err
 }
 
 // WaitForCompletion waits for all pending actions to complete.
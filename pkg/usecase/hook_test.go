@@ -1,6 +1,7 @@
 package usecase_test
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"net/http"
@@ -45,7 +46,7 @@ func TestHookExecutor(t *testing.T) {
 		gt.NoError(t, err)
 	})
 
-	t.Run("Execute handles unknown action type gracefully", func(t *testing.T) {
+	t.Run("Execute records unknown action type as failed", func(t *testing.T) {
 		config := &model.Config{
 			Hooks: model.HooksConfig{
 				CheckSuccess: []model.Action{
@@ -65,6 +66,12 @@ func TestHookExecutor(t *testing.T) {
 
 		err := executor.Execute(context.Background(), event)
 		gt.NoError(t, err)
+		executor.WaitForCompletion()
+
+		results := executor.Results()
+		gt.A(t, results).Length(1).Required()
+		gt.Equal(t, results[0].Status, model.HookStatusFailed)
+		gt.True(t, strings.Contains(results[0].Error, "unknown action type"))
 	})
 
 	t.Run("WaitForCompletion waits for all pending actions", func(t *testing.T) {
@@ -362,6 +369,51 @@ func TestHookExecutor(t *testing.T) {
 		gt.NoError(t, err)
 	})
 
+	t.Run("Actions whose condition is not met are skipped", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("uses a shell command")
+		}
+
+		dir := t.TempDir()
+		mainFile := dir + "/main.txt"
+		featureFile := dir + "/feature.txt"
+
+		config := &model.Config{
+			Hooks: model.HooksConfig{
+				CompleteFailure: []model.Action{
+					{
+						Type: "command",
+						When: `branch == "main"`,
+						Data: map[string]any{
+							"command": "touch",
+							"args":    []string{mainFile},
+						},
+					},
+					{
+						Type: "command",
+						When: `branch == "feature"`,
+						Data: map[string]any{
+							"command": "touch",
+							"args":    []string{featureFile},
+						},
+					},
+				},
+			},
+		}
+
+		executor := usecase.NewHookExecutor(config)
+		err := executor.Execute(context.Background(), model.WorkflowEvent{
+			Type:   model.HookCompleteFailure,
+			Commit: model.Commit{Branch: "main"},
+		})
+		gt.NoError(t, err)
+
+		_, err = os.Stat(mainFile)
+		gt.NoError(t, err)
+		_, err = os.Stat(featureFile)
+		gt.True(t, os.IsNotExist(err))
+	})
+
 	t.Run("Execute multiple action types concurrently", func(t *testing.T) {
 		// Setup test server for Slack
 		var slackCount int32
@@ -592,3 +644,257 @@ func TestHookExecutor(t *testing.T) {
 		gt.Equal(t, strings.TrimSpace(string(content)), "test/repository")
 	})
 }
+
+func TestHookExecutorPipeline(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses shell commands")
+	}
+
+	shell := func(script string, extra map[string]any) model.Action {
+		data := map[string]any{
+			"command": "sh",
+			"args":    []string{"-c", script},
+		}
+		action := model.Action{Type: "command", Data: data}
+		if id, ok := extra["id"].(string); ok {
+			action.ID = id
+		}
+		if needs, ok := extra["needs"].([]string); ok {
+			action.Needs = needs
+		}
+		if when, ok := extra["when"].(string); ok {
+			action.When = when
+		}
+		action.ContinueOnError, _ = extra["continue_on_error"].(bool)
+		return action
+	}
+	execute := func(t *testing.T, hooks model.HooksConfig) {
+		executor := usecase.NewHookExecutor(&model.Config{Hooks: hooks})
+		gt.NoError(t, executor.Execute(context.Background(), model.WorkflowEvent{Type: model.HookCompleteFailure})).Required()
+	}
+	readFile := func(t *testing.T, path string) string {
+		data, err := os.ReadFile(path)
+		gt.NoError(t, err).Required()
+		return string(data)
+	}
+
+	t.Run("needs passes outputs to the next action", func(t *testing.T) {
+		dir := t.TempDir()
+		out := dir + "/out.txt"
+		// Arguments expand environment variables from octap's environment, so
+		// $OCTAP_OUTPUT is referenced from a script
+		script := dir + "/upload.sh"
+		gt.NoError(t, os.WriteFile(script, []byte(`#!/bin/sh
+echo "url=https://example.com/artifact" >> "$OCTAP_OUTPUT"
+echo uploaded
+`), 0700)).Required()
+
+		execute(t, model.HooksConfig{
+			CompleteFailure: []model.Action{
+				{Type: "command", ID: "upload", Data: map[string]any{"command": script}},
+				shell(`echo "{{.Outputs.upload.url}} {{.Outputs.upload.stdout}}" > `+out, map[string]any{
+					"needs": []string{"upload"},
+					"when":  `outputs.upload.url != ""`,
+				}),
+			},
+		})
+		gt.Equal(t, readFile(t, out), "https://example.com/artifact uploaded\n")
+	})
+
+	t.Run("failed needs skip dependents unless continue_on_error", func(t *testing.T) {
+		dir := t.TempDir()
+		execute(t, model.HooksConfig{
+			CompleteFailure: []model.Action{
+				shell("exit 1", map[string]any{"id": "hard"}),
+				shell("touch "+dir+"/after-hard", map[string]any{"id": "after", "needs": []string{"hard"}}),
+				shell("touch "+dir+"/after-after", map[string]any{"needs": []string{"after"}}),
+				shell("exit 1", map[string]any{"id": "soft", "continue_on_error": true}),
+				shell("touch "+dir+"/after-soft", map[string]any{"needs": []string{"soft"}}),
+			},
+		})
+
+		_, err := os.Stat(dir + "/after-hard")
+		gt.True(t, os.IsNotExist(err))
+		_, err = os.Stat(dir + "/after-after")
+		gt.True(t, os.IsNotExist(err))
+		_, err = os.Stat(dir + "/after-soft")
+		gt.NoError(t, err)
+	})
+
+	t.Run("sequential runs actions in order and stops at a failure", func(t *testing.T) {
+		out := t.TempDir() + "/out.txt"
+		execute(t, model.HooksConfig{
+			Sequential: true,
+			CompleteFailure: []model.Action{
+				shell("sleep 0.1; echo first >> "+out, nil),
+				shell("echo second >> "+out, nil),
+				shell("exit 1", nil),
+				shell("echo third >> "+out, nil),
+			},
+		})
+		gt.Equal(t, readFile(t, out), "first\nsecond\n")
+	})
+
+	t.Run("needs of an unknown action is an error", func(t *testing.T) {
+		executor := usecase.NewHookExecutor(&model.Config{Hooks: model.HooksConfig{
+			CompleteFailure: []model.Action{
+				shell("true", map[string]any{"needs": []string{"missing"}}),
+			},
+		}})
+		err := executor.Execute(context.Background(), model.WorkflowEvent{Type: model.HookCompleteFailure})
+		gt.Error(t, err)
+	})
+}
+
+func TestHookExecutorResults(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses shell commands")
+	}
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	executor := usecase.NewHookExecutor(&model.Config{Hooks: model.HooksConfig{
+		CompleteFailure: []model.Action{
+			{Type: "command", ID: "ok", Data: map[string]any{"command": "true"}},
+			{Type: "command", ID: "broken", Data: map[string]any{"command": "false"}},
+			{Type: "command", Needs: []string{"broken"}, Data: map[string]any{"command": "true"}},
+			{Type: "command", When: `branch == "main"`, Data: map[string]any{"command": "true"}},
+			{Type: "webhook", Data: map[string]any{
+				"url":   server.URL,
+				"retry": map[string]any{"attempts": 3, "backoff": "1ms"},
+			}},
+		},
+	}})
+	gt.NoError(t, executor.Execute(context.Background(), model.WorkflowEvent{
+		Type:   model.HookCompleteFailure,
+		Commit: model.Commit{Branch: "feature"},
+	})).Required()
+
+	results := executor.Results()
+	gt.A(t, results).Length(5).Required()
+	gt.Equal(t, results.Count(model.HookStatusSuccess), 2)
+	gt.Equal(t, results.Count(model.HookStatusFailed), 1)
+	gt.Equal(t, results.Count(model.HookStatusSkipped), 2)
+
+	byIndex := make(map[int]model.HookResult)
+	for _, result := range results {
+		gt.Equal(t, result.Event, model.HookCompleteFailure)
+		byIndex[result.Index] = result
+	}
+	gt.Equal(t, byIndex[0].Status, model.HookStatusSuccess)
+	gt.Equal(t, byIndex[0].ID, "ok")
+	gt.Equal(t, byIndex[1].Status, model.HookStatusFailed)
+	gt.True(t, byIndex[1].Error != "")
+	gt.True(t, byIndex[1].Duration > 0)
+	gt.Equal(t, byIndex[2].Status, model.HookStatusSkipped)
+	gt.Equal(t, byIndex[3].Status, model.HookStatusSkipped)
+	gt.Equal(t, byIndex[4].Status, model.HookStatusSuccess)
+	gt.Equal(t, byIndex[4].Type, "webhook")
+	gt.Equal(t, byIndex[4].Retries, 1)
+}
+
+func TestHookExecutorDryRun(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses shell commands")
+	}
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer server.Close()
+
+	marker := t.TempDir() + "/ran"
+	executor := usecase.NewHookExecutor(&model.Config{Hooks: model.HooksConfig{
+		CheckFailure: []model.Action{
+			{Type: "webhook", Data: map[string]any{
+				"url":     server.URL,
+				"headers": map[string]any{"Authorization": "Bearer secret"},
+				"body":    `{"text": "{{.Workflow}} failed"}`,
+			}},
+			{Type: "command", Data: map[string]any{
+				"command": "touch",
+				"args":    []string{marker, "{{.Workflow}}"},
+			}},
+		},
+	}})
+
+	var out bytes.Buffer
+	ctx := usecase.WithDryRun(context.Background(), &out)
+	gt.NoError(t, executor.Execute(ctx, model.WorkflowEvent{Type: model.HookCheckFailure, Workflow: "build"})).Required()
+	executor.WaitForCompletion()
+
+	gt.Equal(t, calls.Load(), int32(0))
+	_, err := os.Stat(marker)
+	gt.True(t, os.IsNotExist(err))
+
+	output := out.String()
+	gt.True(t, strings.Contains(output, "==> [0] webhook on check_failure: success\nPOST http://127.0.0.1:"))
+	gt.False(t, strings.Contains(output, server.URL))
+	gt.True(t, strings.Contains(output, "Authorization: <redacted>"))
+	gt.False(t, strings.Contains(output, "secret"))
+	gt.True(t, strings.Contains(output, `"text": "build failed"`))
+	gt.True(t, strings.Contains(output, "==> [1] command on check_failure: success\n$ \"touch\" \""+marker+"\" \"build\"\n"))
+	gt.A(t, executor.Results()).Length(2)
+}
+
+func TestHookExecutorDryRunRedactsCredentials(t *testing.T) {
+	executor := usecase.NewHookExecutor(&model.Config{Hooks: model.HooksConfig{
+		CheckFailure: []model.Action{
+			{Type: "slack", Data: map[string]any{
+				"webhook_url": "https://hooks.slack.com/services/T0SLACK/B0SLACK/slacksecretpath",
+				"message":     "failed",
+			}},
+			{Type: "discord", Data: map[string]any{
+				"webhook_url": "https://discord.com/api/webhooks/1234/discordsecretpath",
+				"message":     "failed",
+			}},
+			{Type: "teams", Data: map[string]any{
+				"webhook_url": "https://example.webhook.office.com/webhookb2/teamssecretpath",
+				"message":     "failed",
+			}},
+			{Type: "gotify", Data: map[string]any{
+				"server":  "https://gotify.example.com",
+				"token":   "gotifysecrettoken",
+				"message": "failed",
+			}},
+			{Type: "pushover", Data: map[string]any{
+				"token":   "pushoversecrettoken",
+				"user":    "pushoversecretuser",
+				"message": "failed",
+			}},
+			{Type: "webhook", Data: map[string]any{
+				"url":     "https://example.com/hook",
+				"headers": map[string]any{"X-Api-Key": "webhooksecretkey"},
+				"body":    `{"event": {"token": "bodysecrettoken"}, "text": "failed"}`,
+			}},
+		},
+	}})
+
+	var out bytes.Buffer
+	ctx := usecase.WithDryRun(context.Background(), &out)
+	gt.NoError(t, executor.Execute(ctx, model.WorkflowEvent{Type: model.HookCheckFailure, Workflow: "build"})).Required()
+	executor.WaitForCompletion()
+
+	output := out.String()
+	for _, secret := range []string{
+		"slacksecretpath", "discordsecretpath", "teamssecretpath",
+		"gotifysecrettoken", "pushoversecrettoken", "pushoversecretuser",
+		"webhooksecretkey", "bodysecrettoken",
+	} {
+		gt.False(t, strings.Contains(output, secret))
+	}
+	gt.True(t, strings.Contains(output, "X-Gotify-Key: <redacted>"))
+	gt.True(t, strings.Contains(output, "X-Api-Key: <redacted>"))
+	gt.True(t, strings.Contains(output, `"user": "<redacted>"`))
+	gt.True(t, strings.Contains(output, `"text": "failed"`))
+	gt.Equal(t, executor.Results().Count(model.HookStatusSuccess), 6)
+}
@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/m-mizutani/goerr/v2"
+)
+
+// httpStatusError is returned when the server responds with a non-2xx status
+type httpStatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("server returned status %d: %s", e.StatusCode, e.Body)
+}
+
+// retryable reports whether the request may succeed if sent again
+func (e *httpStatusError) retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+// permanentError wraps an error that sending the request again cannot fix,
+// such as an API rejecting a request with a 200 response
+type permanentError struct {
+	error
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.error
+}
+
+// maxResponseSize limits how much of a response body is read
+const maxResponseSize = 1 << 20
+
+// sendRequest sends a single HTTP request and turns non-2xx responses into *httpStatusError
+func sendRequest(ctx context.Context, client *http.Client, method, url string, headers http.Header, body []byte) error {
+	if out := dryRunOutput(ctx); out != nil {
+		writeDryRunRequest(out, method, url, headers, body)
+		return nil
+	}
+
+	_, err := doRequest(ctx, client, method, url, headers, body)
+	return err
+}
+
+// doRequest sends a single HTTP request and returns the body of a 2xx response.
+// Other responses are turned into *httpStatusError. It does not check for a dry run.
+func doRequest(ctx context.Context, client *http.Client, method, url string, headers http.Header, body []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, goerr.Wrap(err, "failed to create request")
+	}
+	req.Header = headers.Clone()
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, goerr.Wrap(err, "failed to send request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		// Best effort to read a short part of the response for the error message
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, &httpStatusError{StatusCode: resp.StatusCode, Body: string(respBody)}
+	}
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, goerr.Wrap(err, "failed to read response")
+	}
+	return respBody, nil
+}
+
+// postJSON marshals payload and POSTs it to url
+func postJSON(ctx context.Context, client *http.Client, url string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return goerr.Wrap(err, "failed to marshal payload")
+	}
+
+	headers := make(http.Header)
+	headers.Set("Content-Type", "application/json")
+	return sendRequest(ctx, client, http.MethodPost, url, headers, body)
+}
+
+type retryCounterKey struct{}
+
+// withRetryCounter returns a context in which retries of requests are counted into the returned counter
+func withRetryCounter(ctx context.Context) (context.Context, *atomic.Int64) {
+	counter := new(atomic.Int64)
+	return context.WithValue(ctx, retryCounterKey{}, counter), counter
+}
+
+// countRetry records a retry in the counter of the context, if any
+func countRetry(ctx context.Context) {
+	if counter, ok := ctx.Value(retryCounterKey{}).(*atomic.Int64); ok {
+		counter.Add(1)
+	}
+}
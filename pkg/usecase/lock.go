@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/m-mizutani/goerr/v2"
+)
+
+// lockMaxAge is how long a lock of a live process is honored. It only guards
+// against a reused PID keeping a lock of a crashed instance forever.
+const lockMaxAge = 24 * time.Hour
+
+// lockStore keeps lock files that tell concurrent octap instances which of them
+// handles something. A lock holds while the process that created it runs.
+type lockStore struct {
+	dir string
+	// err is why locks cannot be taken, such as an unknown home directory
+	err error
+
+	mu     sync.Mutex
+	held   []string // Lock files created by this store, removed on release
+	pruned bool
+}
+
+func newLockStore(dir string) *lockStore {
+	return &lockStore{dir: dir}
+}
+
+// acquire creates the lock file of name and reports whether this process holds it.
+// A lock left behind by a process that is gone is taken over.
+func (s *lockStore) acquire(name string) (bool, error) {
+	if s.err != nil {
+		return false, s.err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return false, goerr.Wrap(err, "failed to create lock directory", goerr.V("dir", s.dir))
+	}
+	if !s.pruned {
+		s.prune()
+		s.pruned = true
+	}
+
+	path := filepath.Join(s.dir, name+".lock")
+	// One retry after removing a stale lock
+	for range 2 {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600) // #nosec G304 - path is constructed from a fixed directory path
+		if err == nil {
+			_, err = fmt.Fprintf(file, "%d\n", os.Getpid())
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return false, goerr.Wrap(err, "failed to write lock file", goerr.V("path", path))
+			}
+			s.held = append(s.held, path)
+			return true, nil
+		}
+		if !os.IsExist(err) {
+			return false, goerr.Wrap(err, "failed to create lock file", goerr.V("path", path))
+		}
+
+		if !lockStale(path) {
+			return false, nil
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return false, goerr.Wrap(err, "failed to remove stale lock file", goerr.V("path", path))
+		}
+	}
+	return false, nil
+}
+
+// release removes the lock files held by this store
+func (s *lockStore) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, path := range s.held {
+		_ = os.Remove(path)
+	}
+	s.held = nil
+}
+
+// prune removes stale lock files so that the directory does not grow
+func (s *lockStore) prune() {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".lock") {
+			continue
+		}
+		path := filepath.Join(s.dir, entry.Name())
+		if lockStale(path) {
+			_ = os.Remove(path)
+		}
+	}
+}
+
+// lockStale reports whether the process that created the lock file is gone
+func lockStale(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	if time.Since(info.ModTime()) >= lockMaxAge {
+		return true
+	}
+
+	data, err := os.ReadFile(path) // #nosec G304 - path is constructed from a fixed directory path
+	if err != nil {
+		return false
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		// A lock whose owner is being written has no PID yet
+		return len(data) > 0
+	}
+	return !processAlive(pid)
+}
+
+// processAlive reports whether a process with the PID runs
+func processAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	if pid == os.Getpid() {
+		return true
+	}
+
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	defer process.Release()
+
+	// FindProcess only succeeds for running processes on Windows
+	if runtime.GOOS == "windows" {
+		return true
+	}
+	err = process.Signal(syscall.Signal(0))
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"sync"
 	"time"
 
 	"github.com/m-mizutani/ctxlog"
@@ -13,10 +14,28 @@ import (
 )
 
 type MonitorUseCase struct {
-	github   interfaces.GitHubService
-	notifier interfaces.Notifier
-	display  interfaces.Display
-	config   *model.MonitorConfig
+	github    interfaces.GitHubService
+	notifier  interfaces.Notifier
+	display   interfaces.Display
+	config    *model.MonitorConfig
+	estimator *durationEstimator
+	history   interfaces.HistoryStore
+	state     interfaces.StateStore
+
+	ignoreSavedState bool
+
+	// firedHooks records hook events dispatched during this session for the history
+	firedHooks []model.SessionHook
+	// resumed is set when progress was restored from a saved state
+	resumed bool
+	// completeFired is set once the complete_* hook has fired for the commit
+	completeFired bool
+	// queuedAlerted holds runs for which run_queued_too_long has fired while they are queued
+	queuedAlerted map[int64]bool
+	// previousConclusions caches the conclusion of each workflow on the previous commit of the branch
+	previousConclusions map[int64]model.WorkflowConclusion
+	// notifications tracks the check hooks dispatched in the background
+	notifications sync.WaitGroup
 }
 
 type MonitorUseCaseOptions struct {
@@ -24,17 +43,37 @@ type MonitorUseCaseOptions struct {
 	Notifier interfaces.Notifier
 	Display  interfaces.Display
 	Config   *model.MonitorConfig
+	// History is optional; when set, the session is recorded on completion
+	History interfaces.HistoryStore
+	// State is optional; when set, progress is persisted after every check so a
+	// restarted monitor resumes without re-firing hooks
+	State interfaces.StateStore
+	// IgnoreSavedState starts from scratch even if State has saved progress
+	IgnoreSavedState bool
 }
 
 func NewMonitorUseCase(opts MonitorUseCaseOptions) *MonitorUseCase {
 	return &MonitorUseCase{
-		github:   opts.GitHub,
-		notifier: opts.Notifier,
-		display:  opts.Display,
-		config:   opts.Config,
+		github:           opts.GitHub,
+		notifier:         opts.Notifier,
+		display:          opts.Display,
+		config:           opts.Config,
+		estimator:        newDurationEstimator(opts.GitHub, opts.Config.Repo),
+		history:          opts.History,
+		state:            opts.State,
+		ignoreSavedState: opts.IgnoreSavedState,
+
+		queuedAlerted:       make(map[int64]bool),
+		previousConclusions: make(map[int64]model.WorkflowConclusion),
 	}
 }
 
+// runEvent is an event about a single workflow run detected by a check
+type runEvent struct {
+	event model.HookEvent
+	run   *model.WorkflowRun
+}
+
 func (u *MonitorUseCase) Execute(ctx context.Context) error {
 	logger := ctxlog.From(ctx)
 	startTime := time.Now()
@@ -42,6 +81,26 @@ func (u *MonitorUseCase) Execute(ctx context.Context) error {
 	completedRuns := make(map[int64]bool)
 	var lastUpdate time.Time
 
+	if state := u.loadState(ctx); state != nil {
+		u.resumed = true
+		u.completeFired = state.CompleteFired
+		u.firedHooks = state.Hooks
+		if !state.StartedAt.IsZero() {
+			startTime = state.StartedAt
+		}
+		for _, run := range state.Runs {
+			knownRuns[run.ID] = run
+		}
+		for _, id := range state.NotifiedRunIDs {
+			completedRuns[id] = true
+		}
+		logger.Info("resuming monitor from saved state",
+			slog.Int("known_runs", len(knownRuns)),
+			slog.Int("notified_runs", len(completedRuns)),
+			slog.Bool("complete_fired", u.completeFired),
+		)
+	}
+
 	logger.Debug("starting monitor",
 		slog.String("repo", u.config.Repo.FullName()),
 		slog.String("commit", u.config.CommitSHA),
@@ -65,6 +124,7 @@ func (u *MonitorUseCase) Execute(ctx context.Context) error {
 	for {
 		select {
 		case <-ctx.Done():
+			u.abort(ctx, knownRuns, startTime)
 			return ctx.Err()
 
 		case <-checkNow:
@@ -124,8 +184,12 @@ func (u *MonitorUseCase) performCheck(
 
 	*lastUpdate = time.Now()
 
+	// Attach duration estimates from workflow history for elapsed/ETA display
+	u.estimator.Apply(ctx, runs)
+
 	// Collect newly completed workflows for notifications
 	var newlyCompleted []*model.WorkflowRun
+	var runEvents []runEvent
 	allCompleted := true
 	hasNewCompletions := false
 
@@ -135,14 +199,23 @@ func (u *MonitorUseCase) performCheck(
 
 		if run.Status != model.WorkflowStatusCompleted {
 			allCompleted = false
+			if event := u.pendingRunEvent(run, previous, exists, isInitial, *lastUpdate); event != "" {
+				runEvents = append(runEvents, runEvent{event: event, run: run})
+			}
+			if (*completedRuns)[run.ID] {
+				// The run was re-run; notify again when the new attempt completes
+				delete(*completedRuns, run.ID)
+				u.completeFired = false
+			}
 			continue
 		}
 
 		if !(*completedRuns)[run.ID] {
 			(*completedRuns)[run.ID] = true
 
-			// Check if this is a new completion (status change)
-			if exists && previous.Status != model.WorkflowStatusCompleted {
+			// Check if this is a new completion (status change). When resuming, any
+			// run not yet notified completed while octap was not running.
+			if (exists && previous.Status != model.WorkflowStatusCompleted) || (isInitial && u.resumed) {
 				hasNewCompletions = true
 				newlyCompleted = append(newlyCompleted, run)
 			} else if isInitial {
@@ -152,19 +225,51 @@ func (u *MonitorUseCase) performCheck(
 		}
 	}
 
+	progress := model.Progress{
+		Total: len(runs),
+		ETA:   model.EstimateCompletion(runs, *lastUpdate),
+		Runs:  runs,
+	}
+	for _, run := range runs {
+		if run.Status == model.WorkflowStatusCompleted {
+			progress.Completed++
+		}
+	}
+
 	// Update display
 	if u.display != nil {
 		u.display.Update(runs, *lastUpdate, u.config.Interval)
 	}
 
+	// Runs that started or are stuck in the queue
+	for _, e := range runEvents {
+		u.recordHook(e.event, e.run.Name)
+		u.notifications.Add(1)
+		go func() {
+			defer u.notifications.Done()
+			u.notifyRun(context.WithoutCancel(ctx), e.event, e.run, progress)
+		}()
+	}
+
 	// Handle sound notifications in background goroutines (non-blocking)
-	// Skip individual notifications on initial check if all are already completed
-	if !(isInitial && allCompleted && len(runs) > 0) {
+	// Skip individual notifications on initial check if all are already completed,
+	// unless resuming: then they are runs that completed while octap was not running
+	if !(isInitial && allCompleted && len(runs) > 0) || u.resumed {
 		for _, workflow := range newlyCompleted {
-			go u.handleWorkflowNotification(ctx, workflow)
+			u.recordHook(checkHookEvent(workflow), workflow.Name)
+			followUp := u.followUpEvent(ctx, workflow)
+			u.recordHook(followUp, workflow.Name)
+			// Hook actions outlive the monitor context so an interrupt doesn't cut them off
+			u.notifications.Add(1)
+			go func() {
+				defer u.notifications.Done()
+				u.handleWorkflowNotification(context.WithoutCancel(ctx), workflow, followUp, progress)
+			}()
 		}
 	}
 
+	u.saveState(ctx, *knownRuns, *completedRuns, startTime)
+
 	// Exit when all workflows are completed
 	if allCompleted && len(runs) > 0 {
 		logger.Debug("All workflows completed",
@@ -173,9 +278,10 @@ func (u *MonitorUseCase) performCheck(
 			slog.Int("run_count", len(runs)),
 		)
 		if isInitial || hasNewCompletions {
-			// Show final summary if display supports it
-			if extDisplay, ok := u.display.(interfaces.ExtendedDisplay); ok {
-				extDisplay.ShowFinalSummary()
+			if u.completeFired {
+				logger.Info("complete hook already fired for this commit, not firing again")
+				u.finishHooks(u.buildSummary(runs, startTime))
+				return errAllCompleted
 			}
 
 			summary := u.buildSummary(runs, startTime)
@@ -184,13 +290,23 @@ func (u *MonitorUseCase) performCheck(
 				slog.Int("success_count", summary.SuccessCount),
 				slog.Int("failure_count", summary.FailureCount),
 			)
-			if err := u.notifier.NotifyComplete(ctx, summary); err != nil {
+			completeEvent := model.HookCompleteSuccess
+			if summary.FailureCount > 0 {
+				completeEvent = model.HookCompleteFailure
+			}
+			u.recordHook(completeEvent, "")
+			u.completeFired = true
+			u.saveState(ctx, *knownRuns, *completedRuns, startTime)
+			if err := u.notifier.NotifyComplete(context.WithoutCancel(ctx), summary); err != nil {
 				logger.Warn("failed to notify completion",
 					slog.String("error", err.Error()),
 				)
 			} else {
 				logger.Debug("NotifyComplete returned successfully")
 			}
+
+			u.saveHistory(ctx, runs, startTime)
+			u.finishHooks(summary)
 			return errAllCompleted
 		}
 	}
@@ -205,13 +321,80 @@ func (u *MonitorUseCase) performCheck(
 	return nil
 }
 
+// abort shows a partial summary and fires the monitor_aborted hook when monitoring
+// is interrupted. Nothing is reported if no workflow run had been seen yet.
+func (u *MonitorUseCase) abort(ctx context.Context, knownRuns map[int64]*model.WorkflowRun, startTime time.Time) {
+	if len(knownRuns) == 0 {
+		if u.display != nil {
+			u.display.Clear()
+		}
+		return
+	}
+	logger := ctxlog.From(ctx)
+
+	runs := make([]*model.WorkflowRun, 0, len(knownRuns))
+	for _, run := range knownRuns {
+		runs = append(runs, run)
+	}
+	summary := u.buildSummary(runs, startTime)
+	// Hooks still running are not waited for; the summary shows those finished so far
+	defer u.showFinalSummary(summary)
+	if summary.PendingCount == 0 {
+		// Interrupted after everything completed; the complete hook handles this
+		return
+	}
+
+	logger.Info("monitoring aborted",
+		slog.Int("total_runs", summary.TotalRuns),
+		slog.Int("pending_count", summary.PendingCount),
+	)
+	u.recordHook(model.HookMonitorAborted, "")
+	// The monitor context is already canceled; hooks must still be able to run
+	if err := u.notifier.NotifyAborted(context.WithoutCancel(ctx), summary); err != nil {
+		logger.Warn("failed to notify abort",
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// finishHooks waits for the hooks of the commit to finish and shows the final summary with their results
+func (u *MonitorUseCase) finishHooks(summary *model.Summary) {
+	u.WaitForHooks()
+	u.showFinalSummary(summary)
+}
+
+// WaitForHooks waits for the hooks dispatched so far to finish. Unlike the
+// notifier's WaitForPendingActions, it also waits for check hooks that are
+// dispatched in the background but have not passed their actions to the notifier yet.
+func (u *MonitorUseCase) WaitForHooks() {
+	u.notifications.Wait()
+	u.notifier.WaitForPendingActions()
+}
+
+// showFinalSummary shows the final summary if the display supports it
+func (u *MonitorUseCase) showFinalSummary(summary *model.Summary) {
+	if extDisplay, ok := u.display.(interfaces.ExtendedDisplay); ok {
+		extDisplay.ShowFinalSummary(summary, u.notifier.HookResults())
+	}
+}
+
 func (u *MonitorUseCase) buildSummary(runs []*model.WorkflowRun, startTime time.Time) *model.Summary {
+	return summarizeRuns(runs, time.Since(startTime).Round(time.Second))
+}
+
+// summarizeRuns counts the results of the runs
+func summarizeRuns(runs []*model.WorkflowRun, duration time.Duration) *model.Summary {
 	summary := &model.Summary{
 		TotalRuns: len(runs),
-		Duration:  time.Since(startTime).Round(time.Second),
+		Duration:  duration,
+		Runs:      runs,
 	}
 
 	for _, run := range runs {
+		if run.Status != model.WorkflowStatusCompleted {
+			summary.PendingCount++
+			continue
+		}
 		switch run.Conclusion {
 		case model.WorkflowConclusionSuccess:
 			summary.SuccessCount++
@@ -225,20 +408,238 @@ func (u *MonitorUseCase) buildSummary(runs []*model.WorkflowRun, startTime time.
 	return summary
 }
 
-func (u *MonitorUseCase) handleWorkflowNotification(ctx context.Context, workflow *model.WorkflowRun) {
+// checkHookEvent returns the check_* hook event for a completed run, or empty if none applies
+func checkHookEvent(workflow *model.WorkflowRun) model.HookEvent {
+	switch workflow.Conclusion {
+	case model.WorkflowConclusionSuccess:
+		return model.HookCheckSuccess
+	case model.WorkflowConclusionFailure:
+		return model.HookCheckFailure
+	case model.WorkflowConclusionCancelled:
+		return model.HookCheckCancelled
+	case model.WorkflowConclusionTimedOut:
+		return model.HookCheckTimedOut
+	case model.WorkflowConclusionSkipped:
+		return model.HookCheckSkipped
+	default:
+		return ""
+	}
+}
+
+// pendingRunEvent returns run_started when a run started executing since the last
+// check, or run_queued_too_long once when it has been queued longer than the queued
+// timeout. Runs already in progress on the initial check are not reported as started.
+func (u *MonitorUseCase) pendingRunEvent(run, previous *model.WorkflowRun, exists, isInitial bool, now time.Time) model.HookEvent {
+	if run.Status != model.WorkflowStatusQueued {
+		delete(u.queuedAlerted, run.ID)
+	}
+
+	switch run.Status {
+	case model.WorkflowStatusInProgress:
+		if (exists && previous.Status != model.WorkflowStatusInProgress) || (!exists && !isInitial) {
+			return model.HookRunStarted
+		}
+	case model.WorkflowStatusQueued:
+		if u.config.QueuedTimeout <= 0 || run.CreatedAt.IsZero() || u.queuedAlerted[run.ID] {
+			return ""
+		}
+		if now.Sub(run.CreatedAt) > u.config.QueuedTimeout {
+			u.queuedAlerted[run.ID] = true
+			return model.HookRunQueuedTooLong
+		}
+	}
+	return ""
+}
+
+// followUpEvent returns first_failure for the first failed run of the commit, and
+// recovered for a successful run whose workflow failed on the previous commit of the branch
+func (u *MonitorUseCase) followUpEvent(ctx context.Context, workflow *model.WorkflowRun) model.HookEvent {
+	switch workflow.Conclusion {
+	case model.WorkflowConclusionFailure:
+		for _, hook := range u.firedHooks {
+			if hook.Event == model.HookFirstFailure {
+				return ""
+			}
+		}
+		return model.HookFirstFailure
+	case model.WorkflowConclusionSuccess:
+		if u.previousConclusion(ctx, workflow) == model.WorkflowConclusionFailure {
+			return model.HookRecovered
+		}
+	}
+	return ""
+}
+
+// previousConclusion returns the conclusion of the workflow on the previous commit of
+// the branch, fetched once per workflow. It is empty when unknown.
+func (u *MonitorUseCase) previousConclusion(ctx context.Context, workflow *model.WorkflowRun) model.WorkflowConclusion {
+	if workflow.WorkflowID == 0 || workflow.Branch == "" {
+		return ""
+	}
+	if conclusion, ok := u.previousConclusions[workflow.WorkflowID]; ok {
+		return conclusion
+	}
+
+	var conclusion model.WorkflowConclusion
+	runs, err := u.github.ListWorkflowRuns(ctx, u.config.Repo, model.RunFilter{
+		Branch:     workflow.Branch,
+		WorkflowID: workflow.WorkflowID,
+		Limit:      10,
+	})
+	if err != nil {
+		// Best effort; a missed recovered event is not worth failing the check
+		ctxlog.From(ctx).Debug("failed to fetch previous runs of workflow",
+			slog.Int64("workflow_id", workflow.WorkflowID),
+			slog.String("error", err.Error()),
+		)
+	}
+	for _, run := range runs {
+		// Runs are newest first; skip runs of this commit and runs still going
+		if run.HeadSHA == workflow.HeadSHA || run.Status != model.WorkflowStatusCompleted {
+			continue
+		}
+		conclusion = run.Conclusion
+		break
+	}
+
+	u.previousConclusions[workflow.WorkflowID] = conclusion
+	return conclusion
+}
+
+func (u *MonitorUseCase) recordHook(event model.HookEvent, workflow string) {
+	if event == "" {
+		return
+	}
+	u.firedHooks = append(u.firedHooks, model.SessionHook{
+		Event:    event,
+		Workflow: workflow,
+		FiredAt:  time.Now(),
+	})
+}
+
+// loadState returns saved progress for the monitored commit, or nil to start from scratch
+func (u *MonitorUseCase) loadState(ctx context.Context) *model.MonitorState {
+	if u.state == nil || u.ignoreSavedState {
+		return nil
+	}
+
+	state, err := u.state.Load(ctx, u.config.Repo.FullName(), u.config.CommitSHA)
+	if err != nil {
+		ctxlog.From(ctx).Warn("failed to load monitor state, starting from scratch",
+			slog.String("error", err.Error()),
+		)
+		return nil
+	}
+	return state
+}
+
+// saveState persists progress so a restarted monitor does not re-fire hooks
+func (u *MonitorUseCase) saveState(ctx context.Context, knownRuns map[int64]*model.WorkflowRun, completedRuns map[int64]bool, startTime time.Time) {
+	if u.state == nil {
+		return
+	}
+
+	state := &model.MonitorState{
+		Repository:    u.config.Repo.FullName(),
+		CommitSHA:     u.config.CommitSHA,
+		StartedAt:     startTime,
+		UpdatedAt:     time.Now(),
+		CompleteFired: u.completeFired,
+		Hooks:         u.firedHooks,
+	}
+	for _, run := range knownRuns {
+		state.Runs = append(state.Runs, run)
+	}
+	for id := range completedRuns {
+		state.NotifiedRunIDs = append(state.NotifiedRunIDs, id)
+	}
+
+	if err := u.state.Save(ctx, state); err != nil {
+		ctxlog.From(ctx).Warn("failed to save monitor state",
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// saveHistory records the finished session in the local history store
+func (u *MonitorUseCase) saveHistory(ctx context.Context, runs []*model.WorkflowRun, startTime time.Time) {
+	if u.history == nil {
+		return
+	}
+	logger := ctxlog.From(ctx)
+
+	session := &model.Session{
+		Repository: u.config.Repo.FullName(),
+		CommitSHA:  u.config.CommitSHA,
+		StartedAt:  startTime,
+		FinishedAt: time.Now(),
+		Conclusion: sessionConclusion(runs),
+		Runs:       runs,
+		Hooks:      u.firedHooks,
+	}
+	for _, run := range runs {
+		if run.Branch != "" {
+			session.Branch = run.Branch
+			break
+		}
+	}
+
+	if err := u.history.Save(ctx, session); err != nil {
+		logger.Warn("failed to save monitoring history",
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// sessionConclusion returns success only if every completed run succeeded or was
+// skipped; runs that timed out or were cancelled did not pass
+func sessionConclusion(runs []*model.WorkflowRun) model.SessionConclusion {
+	for _, run := range runs {
+		if run.Status != model.WorkflowStatusCompleted {
+			continue
+		}
+		switch run.Conclusion {
+		case model.WorkflowConclusionSuccess, model.WorkflowConclusionSkipped:
+		default:
+			return model.SessionConclusionFailure
+		}
+	}
+	return model.SessionConclusionSuccess
+}
+
+// handleWorkflowNotification notifies the check event of a completed run, followed by
+// followUp (first_failure or recovered) when set
+func (u *MonitorUseCase) handleWorkflowNotification(ctx context.Context, workflow *model.WorkflowRun, followUp model.HookEvent, progress model.Progress) {
 	logger := ctxlog.From(ctx)
 	switch workflow.Conclusion {
 	case model.WorkflowConclusionSuccess:
-		if err := u.notifier.NotifySuccess(ctx, workflow); err != nil {
+		if err := u.notifier.NotifySuccess(ctx, workflow, progress); err != nil {
 			logger.Warn("failed to notify success",
 				slog.String("error", err.Error()),
 			)
 		}
 	case model.WorkflowConclusionFailure:
-		if err := u.notifier.NotifyFailure(ctx, workflow); err != nil {
+		if err := u.notifier.NotifyFailure(ctx, workflow, progress); err != nil {
 			logger.Warn("failed to notify failure",
 				slog.String("error", err.Error()),
 			)
 		}
+	default:
+		if event := checkHookEvent(workflow); event != "" {
+			u.notifyRun(ctx, event, workflow, progress)
+		}
+	}
+
+	if followUp != "" {
+		u.notifyRun(ctx, followUp, workflow, progress)
+	}
+}
+
+func (u *MonitorUseCase) notifyRun(ctx context.Context, event model.HookEvent, workflow *model.WorkflowRun, progress model.Progress) {
+	if err := u.notifier.NotifyRun(ctx, event, workflow, progress); err != nil {
+		ctxlog.From(ctx).Warn("failed to notify workflow run event",
+			slog.String("event", string(event)),
+			slog.String("error", err.Error()),
+		)
 	}
 }
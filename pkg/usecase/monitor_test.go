@@ -0,0 +1,412 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/m-mizutani/gt"
+	"github.com/m-mizutani/octap/pkg/domain/interfaces"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+	"github.com/m-mizutani/octap/pkg/usecase"
+)
+
+// monitorGitHubService returns a fixed set of runs for the monitored commit
+type monitorGitHubService struct {
+	interfaces.GitHubService
+	runs []*model.WorkflowRun
+}
+
+func (m *monitorGitHubService) GetWorkflowRuns(ctx context.Context, repo model.Repository, commitSHA string) ([]*model.WorkflowRun, error) {
+	return m.runs, nil
+}
+
+// recordingNotifier records which notifications were sent
+type recordingNotifier struct {
+	mu        sync.Mutex
+	checks    []string
+	runEvents []string
+	completes int
+	aborts    int
+	hooks     model.HookResults
+}
+
+func (r *recordingNotifier) NotifySuccess(ctx context.Context, workflow *model.WorkflowRun, progress model.Progress) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks = append(r.checks, workflow.Name)
+	return nil
+}
+
+func (r *recordingNotifier) NotifyFailure(ctx context.Context, workflow *model.WorkflowRun, progress model.Progress) error {
+	return r.NotifySuccess(ctx, workflow, progress)
+}
+
+func (r *recordingNotifier) NotifyRun(ctx context.Context, event model.HookEvent, workflow *model.WorkflowRun, progress model.Progress) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.runEvents = append(r.runEvents, string(event)+":"+workflow.Name)
+	return nil
+}
+
+func (r *recordingNotifier) NotifyComplete(ctx context.Context, summary *model.Summary) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.completes++
+	return nil
+}
+
+func (r *recordingNotifier) NotifyAborted(ctx context.Context, summary *model.Summary) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.aborts++
+	return nil
+}
+
+func (r *recordingNotifier) SetConfig(config *model.Config) {}
+
+func (r *recordingNotifier) WaitForPendingActions() {}
+
+func (r *recordingNotifier) HookResults() model.HookResults {
+	return r.hooks
+}
+
+func (r *recordingNotifier) result() ([]string, int) {
+	// Check notifications are dispatched in goroutines
+	time.Sleep(50 * time.Millisecond)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.checks...), r.completes
+}
+
+func TestMonitorResumesFromState(t *testing.T) {
+	ctx := context.Background()
+	repo := model.Repository{Owner: "owner", Name: "repo"}
+	completed := func(id int64, name string, conclusion model.WorkflowConclusion) *model.WorkflowRun {
+		return &model.WorkflowRun{ID: id, Name: name, Status: model.WorkflowStatusCompleted, Conclusion: conclusion}
+	}
+	github := &monitorGitHubService{
+		runs: []*model.WorkflowRun{
+			completed(1, "build", model.WorkflowConclusionSuccess),
+			completed(2, "test", model.WorkflowConclusionFailure),
+		},
+	}
+	run := func(store interfaces.StateStore, ignore bool) *recordingNotifier {
+		notifier := &recordingNotifier{}
+		monitor := usecase.NewMonitorUseCase(usecase.MonitorUseCaseOptions{
+			GitHub:           github,
+			Notifier:         notifier,
+			Config:           &model.MonitorConfig{CommitSHA: "abc1234", Interval: time.Second, Repo: repo},
+			State:            store,
+			IgnoreSavedState: ignore,
+		})
+		gt.NoError(t, monitor.Execute(ctx))
+		return notifier
+	}
+
+	t.Run("runs that completed while stopped are notified once", func(t *testing.T) {
+		store := usecase.NewStateStore(t.TempDir())
+		// Killed after notifying build while test was still running
+		gt.NoError(t, store.Save(ctx, &model.MonitorState{
+			Repository: repo.FullName(),
+			CommitSHA:  "abc1234",
+			Runs: []*model.WorkflowRun{
+				completed(1, "build", model.WorkflowConclusionSuccess),
+				{ID: 2, Name: "test", Status: model.WorkflowStatusInProgress},
+			},
+			NotifiedRunIDs: []int64{1},
+		}))
+
+		checks, completes := run(store, false).result()
+		gt.A(t, checks).Length(1).At(0, func(t testing.TB, v string) {
+			gt.Equal(t, v, "test")
+		})
+		gt.Equal(t, completes, 1)
+
+		// Restarting again after completion fires nothing
+		checks, completes = run(store, false).result()
+		gt.A(t, checks).Length(0)
+		gt.Equal(t, completes, 0)
+	})
+
+	t.Run("ignoring saved state behaves like a fresh start", func(t *testing.T) {
+		store := usecase.NewStateStore(t.TempDir())
+		run(store, false)
+
+		checks, completes := run(store, true).result()
+		gt.A(t, checks).Length(0)
+		gt.Equal(t, completes, 1)
+	})
+}
+
+func TestMonitorAbortFiresHook(t *testing.T) {
+	github := &monitorGitHubService{
+		runs: []*model.WorkflowRun{
+			{ID: 1, Name: "build", Status: model.WorkflowStatusCompleted, Conclusion: model.WorkflowConclusionSuccess},
+			{ID: 2, Name: "test", Status: model.WorkflowStatusInProgress},
+		},
+	}
+	notifier := &recordingNotifier{}
+	monitor := usecase.NewMonitorUseCase(usecase.MonitorUseCaseOptions{
+		GitHub:   github,
+		Notifier: notifier,
+		Config: &model.MonitorConfig{
+			CommitSHA: "abc1234",
+			Interval:  time.Hour,
+			Repo:      model.Repository{Owner: "owner", Name: "repo"},
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err := monitor.Execute(ctx)
+	gt.Equal(t, err, context.DeadlineExceeded)
+
+	notifier.mu.Lock()
+	defer notifier.mu.Unlock()
+	gt.Equal(t, notifier.aborts, 1)
+	gt.Equal(t, notifier.completes, 0)
+}
+
+func TestMonitorSavesSessionConclusion(t *testing.T) {
+	completed := func(id int64, conclusion model.WorkflowConclusion) *model.WorkflowRun {
+		return &model.WorkflowRun{ID: id, Name: fmt.Sprintf("run%d", id), Status: model.WorkflowStatusCompleted, Conclusion: conclusion}
+	}
+	testCases := map[string]struct {
+		runs     []*model.WorkflowRun
+		expected model.SessionConclusion
+	}{
+		"all passed or skipped": {
+			runs:     []*model.WorkflowRun{completed(1, model.WorkflowConclusionSuccess), completed(2, model.WorkflowConclusionSkipped)},
+			expected: model.SessionConclusionSuccess,
+		},
+		"a run failed": {
+			runs:     []*model.WorkflowRun{completed(1, model.WorkflowConclusionSuccess), completed(2, model.WorkflowConclusionFailure)},
+			expected: model.SessionConclusionFailure,
+		},
+		"all timed out": {
+			runs:     []*model.WorkflowRun{completed(1, model.WorkflowConclusionTimedOut), completed(2, model.WorkflowConclusionTimedOut)},
+			expected: model.SessionConclusionFailure,
+		},
+		"a run was cancelled": {
+			runs:     []*model.WorkflowRun{completed(1, model.WorkflowConclusionSuccess), completed(2, model.WorkflowConclusionCancelled)},
+			expected: model.SessionConclusionFailure,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			history := usecase.NewHistoryStore(t.TempDir())
+			monitor := usecase.NewMonitorUseCase(usecase.MonitorUseCaseOptions{
+				GitHub:   &monitorGitHubService{runs: tc.runs},
+				Notifier: &recordingNotifier{},
+				Config: &model.MonitorConfig{
+					CommitSHA: "abc1234",
+					Interval:  time.Second,
+					Repo:      model.Repository{Owner: "owner", Name: "repo"},
+				},
+				History: history,
+			})
+			gt.NoError(t, monitor.Execute(ctx)).Required()
+
+			sessions, err := history.List(ctx, model.HistoryFilter{})
+			gt.NoError(t, err).Required()
+			gt.A(t, sessions).Length(1).Required()
+			gt.Equal(t, sessions[0].Conclusion, tc.expected)
+		})
+	}
+}
+
+// slowNotifier takes a while to send check notifications
+type slowNotifier struct {
+	recordingNotifier
+}
+
+func (s *slowNotifier) NotifySuccess(ctx context.Context, workflow *model.WorkflowRun, progress model.Progress) error {
+	time.Sleep(200 * time.Millisecond)
+	return s.recordingNotifier.NotifySuccess(ctx, workflow, progress)
+}
+
+func TestMonitorWaitForHooksAfterAbort(t *testing.T) {
+	test := &model.WorkflowRun{ID: 2, Name: "test", Status: model.WorkflowStatusInProgress}
+	github := &sequenceGitHubService{
+		steps: [][]*model.WorkflowRun{
+			{{ID: 1, Name: "build", Status: model.WorkflowStatusInProgress}, test},
+			{{ID: 1, Name: "build", Status: model.WorkflowStatusCompleted, Conclusion: model.WorkflowConclusionSuccess}, test},
+		},
+	}
+	notifier := &slowNotifier{}
+	monitor := usecase.NewMonitorUseCase(usecase.MonitorUseCaseOptions{
+		GitHub:   github,
+		Notifier: notifier,
+		Config: &model.MonitorConfig{
+			CommitSHA: "abc1234",
+			Interval:  10 * time.Millisecond,
+			Repo:      model.Repository{Owner: "owner", Name: "repo"},
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	gt.Equal(t, monitor.Execute(ctx), context.DeadlineExceeded)
+
+	// The check hook dispatched before the interrupt is still running
+	monitor.WaitForHooks()
+	notifier.mu.Lock()
+	defer notifier.mu.Unlock()
+	gt.Equal(t, notifier.checks, []string{"build"})
+}
+
+// sequenceGitHubService returns the next set of runs on every check, repeating the last
+type sequenceGitHubService struct {
+	interfaces.GitHubService
+	mu       sync.Mutex
+	steps    [][]*model.WorkflowRun
+	calls    int
+	previous []*model.WorkflowRun
+}
+
+func (s *sequenceGitHubService) GetWorkflowRuns(ctx context.Context, repo model.Repository, commitSHA string) ([]*model.WorkflowRun, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	step := s.steps[min(s.calls, len(s.steps)-1)]
+	s.calls++
+	return step, nil
+}
+
+func (s *sequenceGitHubService) GetRecentWorkflowRuns(ctx context.Context, repo model.Repository, workflowID int64, limit int) ([]*model.WorkflowRun, error) {
+	return nil, nil
+}
+
+func (s *sequenceGitHubService) ListWorkflowRuns(ctx context.Context, repo model.Repository, filter model.RunFilter) ([]*model.WorkflowRun, error) {
+	var runs []*model.WorkflowRun
+	for _, run := range s.previous {
+		if run.WorkflowID == filter.WorkflowID && run.Branch == filter.Branch {
+			runs = append(runs, run)
+		}
+	}
+	return runs, nil
+}
+
+func TestMonitorRunLifecycleEvents(t *testing.T) {
+	now := time.Now()
+	run := func(id int64, name string, status model.WorkflowStatus, conclusion model.WorkflowConclusion) *model.WorkflowRun {
+		return &model.WorkflowRun{
+			ID:         id,
+			WorkflowID: id * 10,
+			Name:       name,
+			Branch:     "main",
+			HeadSHA:    "abc1234",
+			Status:     status,
+			Conclusion: conclusion,
+			CreatedAt:  now.Add(-time.Minute),
+		}
+	}
+	queuedLong := run(1, "build", model.WorkflowStatusQueued, "")
+	queuedLong.CreatedAt = now.Add(-time.Hour)
+
+	github := &sequenceGitHubService{
+		steps: [][]*model.WorkflowRun{
+			{
+				queuedLong,
+				run(2, "test", model.WorkflowStatusInProgress, ""),
+				run(3, "lint", model.WorkflowStatusQueued, ""),
+				run(4, "deploy", model.WorkflowStatusInProgress, ""),
+			},
+			{
+				run(1, "build", model.WorkflowStatusInProgress, ""),
+				run(2, "test", model.WorkflowStatusCompleted, model.WorkflowConclusionFailure),
+				run(3, "lint", model.WorkflowStatusInProgress, ""),
+				run(4, "deploy", model.WorkflowStatusInProgress, ""),
+			},
+			{
+				run(1, "build", model.WorkflowStatusCompleted, model.WorkflowConclusionCancelled),
+				run(2, "test", model.WorkflowStatusCompleted, model.WorkflowConclusionFailure),
+				run(3, "lint", model.WorkflowStatusCompleted, model.WorkflowConclusionSuccess),
+				run(4, "deploy", model.WorkflowStatusCompleted, model.WorkflowConclusionFailure),
+			},
+		},
+		previous: []*model.WorkflowRun{
+			// lint failed on the previous commit of main
+			{ID: 30, WorkflowID: 30, Name: "lint", Branch: "main", HeadSHA: "old", Status: model.WorkflowStatusCompleted, Conclusion: model.WorkflowConclusionFailure},
+		},
+	}
+	notifier := &recordingNotifier{}
+	monitor := usecase.NewMonitorUseCase(usecase.MonitorUseCaseOptions{
+		GitHub:   github,
+		Notifier: notifier,
+		Config: &model.MonitorConfig{
+			CommitSHA:     "abc1234",
+			Interval:      10 * time.Millisecond,
+			Repo:          model.Repository{Owner: "owner", Name: "repo"},
+			QueuedTimeout: 10 * time.Minute,
+		},
+	})
+	gt.NoError(t, monitor.Execute(context.Background())).Required()
+
+	checks, completes := notifier.result()
+	notifier.mu.Lock()
+	runEvents := append([]string(nil), notifier.runEvents...)
+	notifier.mu.Unlock()
+	slices.Sort(checks)
+	slices.Sort(runEvents)
+
+	gt.Equal(t, checks, []string{"deploy", "lint", "test"})
+	gt.Equal(t, runEvents, []string{
+		"check_cancelled:build",
+		"first_failure:test",
+		"recovered:lint",
+		"run_queued_too_long:build",
+		"run_started:build",
+		"run_started:lint",
+	})
+	gt.Equal(t, completes, 1)
+}
+
+// summaryDisplay records the summary and hook results passed to the final summary
+type summaryDisplay struct {
+	summary   *model.Summary
+	hooks     model.HookResults
+	summaries int
+}
+
+func (d *summaryDisplay) Update(runs []*model.WorkflowRun, lastUpdate time.Time, interval time.Duration) {
+}
+func (d *summaryDisplay) ShowWaiting(commitSHA, repoName string) {}
+func (d *summaryDisplay) Clear()                                 {}
+func (d *summaryDisplay) ShowCountdown(remaining time.Duration)  {}
+func (d *summaryDisplay) ShowFinalSummary(summary *model.Summary, hooks model.HookResults) {
+	d.summary = summary
+	d.hooks = hooks
+	d.summaries++
+}
+
+func TestMonitorShowsHookResults(t *testing.T) {
+	github := &monitorGitHubService{
+		runs: []*model.WorkflowRun{
+			{ID: 1, Name: "build", Status: model.WorkflowStatusCompleted, Conclusion: model.WorkflowConclusionFailure},
+		},
+	}
+	notifier := &recordingNotifier{hooks: model.HookResults{
+		{Event: model.HookCompleteFailure, Type: "slack", Status: model.HookStatusFailed, Error: "boom"},
+	}}
+	display := &summaryDisplay{}
+	monitor := usecase.NewMonitorUseCase(usecase.MonitorUseCaseOptions{
+		GitHub:   github,
+		Notifier: notifier,
+		Display:  display,
+		Config:   &model.MonitorConfig{CommitSHA: "abc1234", Interval: time.Second, Repo: model.Repository{Owner: "owner", Name: "repo"}},
+	})
+	gt.NoError(t, monitor.Execute(context.Background())).Required()
+
+	gt.Equal(t, display.summaries, 1)
+	gt.A(t, display.hooks).Length(1)
+	gt.Equal(t, notifier.completes, 1)
+	// The display shows the same summary that was sent to the complete hook
+	gt.V(t, display.summary).NotNil().Required()
+	gt.Equal(t, display.summary.TotalRuns, 1)
+	gt.Equal(t, display.summary.FailureCount, 1)
+}
@@ -5,6 +5,7 @@ import (
 	"log/slog"
 	"os/exec"
 	"runtime"
+	"time"
 
 	"github.com/m-mizutani/ctxlog"
 	"github.com/m-mizutani/octap/pkg/domain/interfaces"
@@ -20,7 +21,7 @@ func NewSoundNotifier() interfaces.Notifier {
 	return &SoundNotifier{}
 }
 
-func (n *SoundNotifier) NotifySuccess(ctx context.Context, workflow *model.WorkflowRun) error {
+func (n *SoundNotifier) NotifySuccess(ctx context.Context, workflow *model.WorkflowRun, progress model.Progress) error {
 	logger := ctxlog.From(ctx)
 	logger.Debug("workflow succeeded",
 		slog.String("name", workflow.Name),
@@ -29,18 +30,7 @@ func (n *SoundNotifier) NotifySuccess(ctx context.Context, workflow *model.Workf
 
 	// Execute hooks if configured
 	if n.hookExecutor != nil {
-		event := model.WorkflowEvent{
-			Type:       model.HookCheckSuccess,
-			Repository: workflow.Repository,
-			Workflow:   workflow.Name,
-			RunID:      workflow.ID,
-			URL:        workflow.URL,
-		}
-		if err := n.hookExecutor.Execute(ctx, event); err != nil {
-			logger.Warn("failed to execute hooks",
-				slog.String("error", err.Error()),
-			)
-		}
+		n.executeRunHooks(ctx, model.HookCheckSuccess, workflow, progress)
 		return nil
 	}
 
@@ -48,7 +38,7 @@ func (n *SoundNotifier) NotifySuccess(ctx context.Context, workflow *model.Workf
 	return n.playSystemSound(ctx, true)
 }
 
-func (n *SoundNotifier) NotifyFailure(ctx context.Context, workflow *model.WorkflowRun) error {
+func (n *SoundNotifier) NotifyFailure(ctx context.Context, workflow *model.WorkflowRun, progress model.Progress) error {
 	logger := ctxlog.From(ctx)
 	logger.Debug("workflow failed",
 		slog.String("name", workflow.Name),
@@ -57,18 +47,7 @@ func (n *SoundNotifier) NotifyFailure(ctx context.Context, workflow *model.Workf
 
 	// Execute hooks if configured
 	if n.hookExecutor != nil {
-		event := model.WorkflowEvent{
-			Type:       model.HookCheckFailure,
-			Repository: workflow.Repository,
-			Workflow:   workflow.Name,
-			RunID:      workflow.ID,
-			URL:        workflow.URL,
-		}
-		if err := n.hookExecutor.Execute(ctx, event); err != nil {
-			logger.Warn("failed to execute hooks",
-				slog.String("error", err.Error()),
-			)
-		}
+		n.executeRunHooks(ctx, model.HookCheckFailure, workflow, progress)
 		return nil
 	}
 
@@ -76,6 +55,44 @@ func (n *SoundNotifier) NotifyFailure(ctx context.Context, workflow *model.Workf
 	return n.playSystemSound(ctx, false)
 }
 
+func (n *SoundNotifier) NotifyRun(ctx context.Context, eventType model.HookEvent, workflow *model.WorkflowRun, progress model.Progress) error {
+	ctxlog.From(ctx).Debug("workflow run event",
+		slog.String("event", string(eventType)),
+		slog.String("name", workflow.Name),
+		slog.Int64("id", workflow.ID),
+	)
+
+	if n.hookExecutor != nil {
+		n.executeRunHooks(ctx, eventType, workflow, progress)
+	}
+	return nil
+}
+
+// executeRunHooks runs the hooks of an event about a single workflow run
+func (n *SoundNotifier) executeRunHooks(ctx context.Context, eventType model.HookEvent, workflow *model.WorkflowRun, progress model.Progress) {
+	event := runHookEvent(eventType, workflow, progress, time.Now())
+	if err := n.hookExecutor.Execute(ctx, event); err != nil {
+		ctxlog.From(ctx).Warn("failed to execute hooks",
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// runHookEvent builds the event of hooks about a single workflow run
+func runHookEvent(eventType model.HookEvent, workflow *model.WorkflowRun, progress model.Progress, now time.Time) model.WorkflowEvent {
+	return model.WorkflowEvent{
+		Type:       eventType,
+		Repository: workflow.Repository,
+		Workflow:   workflow.Name,
+		RunID:      workflow.ID,
+		URL:        workflow.URL,
+		Duration:   workflow.Elapsed(now),
+		ETA:        progress.ETA,
+		Runs:       progress.Runs,
+		Commit:     model.NewCommit(workflow.HeadSHA, append([]*model.WorkflowRun{workflow}, progress.Runs...)),
+	}
+}
+
 func (n *SoundNotifier) NotifyComplete(ctx context.Context, summary *model.Summary) error {
 	logger := ctxlog.From(ctx)
 	logger.Debug("NotifyComplete called",
@@ -96,9 +113,7 @@ func (n *SoundNotifier) NotifyComplete(ctx context.Context, summary *model.Summa
 			logger.Debug("Using HookCompleteSuccess event type")
 		}
 
-		event := model.WorkflowEvent{
-			Type: eventType,
-		}
+		event := summaryEvent(eventType, summary)
 		logger.Debug("Calling hookExecutor.Execute",
 			slog.String("event_type", string(eventType)),
 		)
@@ -120,6 +135,46 @@ func (n *SoundNotifier) NotifyComplete(ctx context.Context, summary *model.Summa
 	return n.playSystemSound(ctx, true)
 }
 
+func (n *SoundNotifier) NotifyAborted(ctx context.Context, summary *model.Summary) error {
+	logger := ctxlog.From(ctx)
+	logger.Debug("NotifyAborted called",
+		slog.Int("total_runs", summary.TotalRuns),
+		slog.Int("pending_count", summary.PendingCount),
+	)
+
+	// No sound fallback: the user interrupted monitoring and is at the terminal
+	if n.hookExecutor == nil {
+		return nil
+	}
+
+	event := summaryEvent(model.HookMonitorAborted, summary)
+	if err := n.hookExecutor.Execute(ctx, event); err != nil {
+		logger.Warn("failed to execute hooks",
+			slog.String("error", err.Error()),
+		)
+	}
+	return nil
+}
+
+// summaryEvent builds the event of complete and aborted hooks, which describe
+// the whole commit rather than a single run
+func summaryEvent(eventType model.HookEvent, summary *model.Summary) model.WorkflowEvent {
+	event := model.WorkflowEvent{
+		Type:     eventType,
+		Duration: summary.Duration,
+		Runs:     summary.Runs,
+		Commit:   model.NewCommit("", summary.Runs),
+		Summary:  summary,
+	}
+	for _, run := range summary.Runs {
+		if run.Repository != "" {
+			event.Repository = run.Repository
+			break
+		}
+	}
+	return event
+}
+
 func (n *SoundNotifier) playSystemSound(ctx context.Context, success bool) error {
 	logger := ctxlog.From(ctx)
 
@@ -185,11 +240,15 @@ func NewNoOpNotifier() interfaces.Notifier {
 	return &NoOpNotifier{}
 }
 
-func (n *NoOpNotifier) NotifySuccess(ctx context.Context, workflow *model.WorkflowRun) error {
+func (n *NoOpNotifier) NotifySuccess(ctx context.Context, workflow *model.WorkflowRun, progress model.Progress) error {
+	return nil
+}
+
+func (n *NoOpNotifier) NotifyFailure(ctx context.Context, workflow *model.WorkflowRun, progress model.Progress) error {
 	return nil
 }
 
-func (n *NoOpNotifier) NotifyFailure(ctx context.Context, workflow *model.WorkflowRun) error {
+func (n *NoOpNotifier) NotifyRun(ctx context.Context, event model.HookEvent, workflow *model.WorkflowRun, progress model.Progress) error {
 	return nil
 }
 
@@ -197,7 +256,7 @@ func (n *SoundNotifier) SetConfig(config *model.Config) {
 	logger := ctxlog.From(context.Background())
 	if config != nil {
 		n.config = config
-		n.hookExecutor = NewHookExecutor(config)
+		n.hookExecutor = NewPolicyHookExecutor(config, "")
 		logger.Debug("SoundNotifier.SetConfig: hookExecutor created",
 			slog.Bool("has_config", config != nil),
 			slog.Int("check_success_count", len(config.Hooks.CheckSuccess)),
@@ -214,6 +273,10 @@ func (n *NoOpNotifier) NotifyComplete(ctx context.Context, summary *model.Summar
 	return nil
 }
 
+func (n *NoOpNotifier) NotifyAborted(ctx context.Context, summary *model.Summary) error {
+	return nil
+}
+
 func (n *NoOpNotifier) SetConfig(config *model.Config) {
 	// NoOp
 }
@@ -222,9 +285,21 @@ func (n *NoOpNotifier) WaitForPendingActions() {
 	// NoOp - no actions to wait for
 }
 
+func (n *NoOpNotifier) HookResults() model.HookResults {
+	return nil
+}
+
 // WaitForPendingActions waits for all pending hook actions to complete.
 func (n *SoundNotifier) WaitForPendingActions() {
 	if n.hookExecutor != nil {
 		n.hookExecutor.WaitForCompletion()
 	}
 }
+
+// HookResults returns the outcome of the hook actions finished so far
+func (n *SoundNotifier) HookResults() model.HookResults {
+	if n.hookExecutor == nil {
+		return nil
+	}
+	return n.hookExecutor.Results()
+}
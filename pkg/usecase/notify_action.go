@@ -0,0 +1,234 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os/exec"
+	"runtime"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/m-mizutani/ctxlog"
+	"github.com/m-mizutani/goerr/v2"
+	"github.com/m-mizutani/octap/pkg/domain/interfaces"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+)
+
+const (
+	notificationsService   = "org.freedesktop.Notifications"
+	notificationsPath      = "/org/freedesktop/Notifications"
+	notificationsInterface = "org.freedesktop.Notifications"
+
+	// notifyDefaultAction is invoked when the notification body is clicked
+	notifyDefaultAction = "default"
+)
+
+// desktopNotification is a rendered notification ready to be sent
+type desktopNotification struct {
+	Title   string
+	Body    string
+	Icon    string
+	Urgency byte
+	// Timeout is in milliseconds; -1 lets the notification server decide
+	Timeout int32
+	Actions []string
+	URL     string
+}
+
+type notifyAction struct{}
+
+// NewNotifyAction creates a new NotifyAction instance
+func NewNotifyAction() interfaces.ActionExecutor {
+	return &notifyAction{}
+}
+
+// Execute shows a desktop notification through the freedesktop notification service on D-Bus
+func (n *notifyAction) Execute(ctx context.Context, action model.Action, event model.WorkflowEvent) error {
+	logger := ctxlog.From(ctx)
+
+	logger.Debug("notifyAction.Execute called",
+		slog.String("event_type", string(event.Type)),
+		slog.Any("action_data", action.Data),
+	)
+
+	notifyAction, err := action.ToNotifyAction()
+	if err != nil {
+		logger.Error("Failed to parse notify action",
+			slog.String("error", err.Error()),
+		)
+		return goerr.Wrap(err, "failed to parse notify action")
+	}
+
+	notification, err := n.buildNotification(notifyAction, event)
+	if err != nil {
+		return err
+	}
+
+	if out := dryRunOutput(ctx); out != nil {
+		_, _ = fmt.Fprintf(out, "title: %s\nbody: %s\n", notification.Title, notification.Body)
+		if notification.URL != "" && len(notification.Actions) > 0 {
+			_, _ = fmt.Fprintf(out, "click: %s\n", notification.URL)
+		}
+		return nil
+	}
+
+	conn, err := dbus.ConnectSessionBus(dbus.WithContext(ctx))
+	if err != nil {
+		return goerr.Wrap(err, "failed to connect to D-Bus session bus; notify action requires a freedesktop notification service",
+			goerr.V("os", runtime.GOOS))
+	}
+
+	// Subscribe before sending so a quick click isn't missed
+	signals := make(chan *dbus.Signal, 8)
+	if len(notification.Actions) > 0 {
+		if err := conn.AddMatchSignal(
+			dbus.WithMatchObjectPath(notificationsPath),
+			dbus.WithMatchInterface(notificationsInterface),
+		); err != nil {
+			_ = conn.Close()
+			return goerr.Wrap(err, "failed to subscribe to notification signals")
+		}
+		conn.Signal(signals)
+	}
+
+	var id uint32
+	obj := conn.Object(notificationsService, notificationsPath)
+	call := obj.CallWithContext(ctx, notificationsInterface+".Notify", 0,
+		"octap",
+		uint32(0), // replaces_id
+		notification.Icon,
+		notification.Title,
+		notification.Body,
+		notification.Actions,
+		map[string]dbus.Variant{"urgency": dbus.MakeVariant(notification.Urgency)},
+		notification.Timeout,
+	)
+	if err := call.Store(&id); err != nil {
+		_ = conn.Close()
+		return goerr.Wrap(err, "failed to send desktop notification")
+	}
+
+	logger.Debug("Desktop notification sent",
+		slog.Uint64("id", uint64(id)),
+		slog.String("title", notification.Title),
+	)
+
+	if len(notification.Actions) == 0 {
+		return conn.Close()
+	}
+
+	if notifyAction.Wait > 0 {
+		// Keep the action (and octap) alive to handle a click
+		n.handleClicks(ctx, conn, signals, id, notification.URL, notifyAction.Wait)
+		return nil
+	}
+
+	// Handle clicks for as long as octap keeps running without delaying exit
+	go n.handleClicks(context.WithoutCancel(ctx), conn, signals, id, notification.URL, 0)
+	return nil
+}
+
+// buildNotification renders templates and converts options to D-Bus values
+func (n *notifyAction) buildNotification(action *model.NotifyAction, event model.WorkflowEvent) (*desktopNotification, error) {
+	title, err := renderTemplate("title", action.Title, event)
+	if err != nil {
+		return nil, goerr.Wrap(err, "failed to build notification title")
+	}
+	body, err := renderTemplate("body", action.Body, event)
+	if err != nil {
+		return nil, goerr.Wrap(err, "failed to build notification body")
+	}
+
+	notification := &desktopNotification{
+		Title:   title,
+		Body:    body,
+		Icon:    action.Icon,
+		Timeout: -1,
+		URL:     event.URL,
+	}
+
+	switch action.Urgency {
+	case model.NotifyUrgencyLow:
+		notification.Urgency = 0
+	case model.NotifyUrgencyCritical:
+		notification.Urgency = 2
+	default:
+		notification.Urgency = 1
+	}
+
+	if action.Expire > 0 {
+		notification.Timeout = int32(action.Expire.Milliseconds())
+	}
+
+	// Complete events have no single run to open
+	if action.OpenRun && event.URL != "" {
+		notification.Actions = []string{notifyDefaultAction, "Open run"}
+	}
+
+	return notification, nil
+}
+
+// handleClicks opens the run URL when the notification's action is invoked. It
+// returns when the notification is closed, the wait elapses (if non-zero) or ctx ends.
+func (n *notifyAction) handleClicks(ctx context.Context, conn *dbus.Conn, signals <-chan *dbus.Signal, id uint32, url string, wait time.Duration) {
+	logger := ctxlog.From(ctx)
+	defer conn.Close()
+
+	var timeout <-chan time.Time
+	if wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timeout:
+			return
+		case signal, ok := <-signals:
+			if !ok {
+				return
+			}
+			if len(signal.Body) == 0 {
+				continue
+			}
+			if signalID, ok := signal.Body[0].(uint32); !ok || signalID != id {
+				continue
+			}
+
+			switch signal.Name {
+			case notificationsInterface + ".ActionInvoked":
+				logger.Debug("Notification clicked, opening run", slog.String("url", url))
+				if err := openURL(url); err != nil {
+					logger.Warn("failed to open run URL",
+						slog.String("url", url),
+						slog.String("error", err.Error()),
+					)
+				}
+				return
+			case notificationsInterface + ".NotificationClosed":
+				return
+			}
+		}
+	}
+}
+
+// openURL opens url in the default browser
+func openURL(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url) // #nosec G204 - url is a GitHub run URL
+	default:
+		cmd = exec.Command("xdg-open", url) // #nosec G204 - url is a GitHub run URL
+	}
+	if err := cmd.Start(); err != nil {
+		return goerr.Wrap(err, "failed to start browser")
+	}
+	// Reap the child without blocking the caller
+	go func() { _ = cmd.Wait() }()
+	return nil
+}
@@ -0,0 +1,53 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m-mizutani/gt"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+	"github.com/m-mizutani/octap/pkg/usecase"
+)
+
+func TestBuildNotification(t *testing.T) {
+	event := model.WorkflowEvent{
+		Type:       model.HookCheckFailure,
+		Repository: "owner/repo",
+		Workflow:   "test",
+		URL:        "https://github.com/owner/repo/actions/runs/1",
+	}
+
+	t.Run("renders templates and adds open action", func(t *testing.T) {
+		notification, err := usecase.BuildNotification(&model.NotifyAction{
+			Title:   "{{.Workflow}} failed",
+			Body:    "{{.Repository}}",
+			Urgency: model.NotifyUrgencyCritical,
+			Expire:  5 * time.Second,
+			OpenRun: true,
+		}, event)
+		gt.NoError(t, err)
+		gt.Equal(t, notification.Title, "test failed")
+		gt.Equal(t, notification.Body, "owner/repo")
+		gt.Equal(t, notification.Urgency, byte(2))
+		gt.Equal(t, notification.Timeout, int32(5000))
+		gt.A(t, notification.Actions).Length(2)
+		gt.Equal(t, notification.URL, event.URL)
+	})
+
+	t.Run("no open action without a run URL", func(t *testing.T) {
+		notification, err := usecase.BuildNotification(&model.NotifyAction{
+			Title:   "octap",
+			Body:    "all done",
+			OpenRun: true,
+		}, model.WorkflowEvent{Type: model.HookCompleteSuccess})
+		gt.NoError(t, err)
+		gt.A(t, notification.Actions).Length(0)
+		gt.Equal(t, notification.Urgency, byte(1))
+		gt.Equal(t, notification.Timeout, int32(-1))
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		_, err := usecase.BuildNotification(&model.NotifyAction{Title: "octap", Body: "{{.Unknown"}, event)
+		gt.Error(t, err)
+	})
+}
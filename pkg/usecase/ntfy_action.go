@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/m-mizutani/ctxlog"
+	"github.com/m-mizutani/goerr/v2"
+	"github.com/m-mizutani/octap/pkg/domain/interfaces"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+)
+
+// ntfyPriorities maps push priorities to ntfy's 1 (min) to 5 (max) scale
+var ntfyPriorities = map[model.PushPriority]int{
+	model.PushPriorityMin:     1,
+	model.PushPriorityLow:     2,
+	model.PushPriorityDefault: 3,
+	model.PushPriorityHigh:    4,
+	model.PushPriorityUrgent:  5,
+}
+
+type ntfyAction struct {
+	httpClient *http.Client
+}
+
+// NewNtfyAction creates a new NtfyAction instance
+func NewNtfyAction() interfaces.ActionExecutor {
+	return &ntfyAction{
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// Execute publishes a message to an ntfy topic
+func (n *ntfyAction) Execute(ctx context.Context, action model.Action, event model.WorkflowEvent) error {
+	logger := ctxlog.From(ctx)
+
+	logger.Debug("ntfyAction.Execute called",
+		slog.String("event_type", string(event.Type)),
+	)
+
+	ntfyAction, err := action.ToNtfyAction()
+	if err != nil {
+		logger.Error("Failed to parse ntfy action",
+			slog.String("error", err.Error()),
+		)
+		return goerr.Wrap(err, "failed to parse ntfy action")
+	}
+
+	server, err := renderField("server", ntfyAction.Server, event)
+	if err != nil {
+		return goerr.Wrap(err, "failed to build ntfy server URL")
+	}
+	server = strings.TrimSuffix(server, "/")
+
+	payload, err := n.buildPayload(ntfyAction, event)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return goerr.Wrap(err, "failed to marshal ntfy payload")
+	}
+
+	// Publishing JSON to the server root keeps non-ASCII titles intact,
+	// which the header based API does not
+	headers := make(http.Header)
+	headers.Set("Content-Type", "application/json")
+	if token := expandEnvVars(ntfyAction.Token); token != "" {
+		headers.Set("Authorization", "Bearer "+token)
+	}
+
+	err = sendRequest(ctx, n.httpClient, http.MethodPost, server+"/", headers, body)
+	if err != nil {
+		return goerr.Wrap(err, "failed to send ntfy notification",
+			goerr.V("server", server),
+			goerr.V("topic", ntfyAction.Topic),
+		)
+	}
+
+	logger.Debug("ntfy notification sent successfully", slog.String("topic", ntfyAction.Topic))
+	return nil
+}
+
+// buildPayload renders the templates into an ntfy message
+func (n *ntfyAction) buildPayload(action *model.NtfyAction, event model.WorkflowEvent) (*model.NtfyPayload, error) {
+	title, message, err := renderPushMessage(action.Title, action.Message, event)
+	if err != nil {
+		return nil, goerr.Wrap(err, "failed to build ntfy message")
+	}
+	topic, err := renderField("topic", action.Topic, event)
+	if err != nil {
+		return nil, goerr.Wrap(err, "failed to build ntfy topic")
+	}
+
+	return &model.NtfyPayload{
+		Topic:    topic,
+		Title:    title,
+		Message:  message,
+		Priority: ntfyPriorities[pushPriority(action.Priority, event.Type)],
+		Tags:     action.Tags,
+		Click:    event.URL,
+	}, nil
+}
@@ -0,0 +1,87 @@
+package usecase_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/m-mizutani/gt"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+	"github.com/m-mizutani/octap/pkg/usecase"
+)
+
+func TestNtfyAction(t *testing.T) {
+	var received model.NtfyPayload
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		gt.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+
+	t.Setenv("OCTAP_TEST_NTFY_TOKEN", "tk_secret")
+	action := model.Action{
+		Type: "ntfy",
+		Data: map[string]interface{}{
+			"server":  server.URL,
+			"topic":   "ci",
+			"token":   "${OCTAP_TEST_NTFY_TOKEN}",
+			"title":   "{{.Workflow}} failed",
+			"message": "{{.Repository}}",
+			"tags":    []interface{}{"x"},
+		},
+	}
+	event := model.WorkflowEvent{
+		Type:       model.HookCheckFailure,
+		Repository: "owner/repo",
+		Workflow:   "build",
+		URL:        "https://github.com/owner/repo/actions/runs/1",
+	}
+
+	gt.NoError(t, usecase.NewNtfyAction().Execute(context.Background(), action, event)).Required()
+	gt.Equal(t, authorization, "Bearer tk_secret")
+	gt.Equal(t, received.Topic, "ci")
+	gt.Equal(t, received.Title, "build failed")
+	gt.Equal(t, received.Message, "owner/repo")
+	gt.Equal(t, received.Priority, 4)
+	gt.Equal(t, received.Click, event.URL)
+	gt.Equal(t, received.Tags, []string{"x"})
+}
+
+func TestNtfyActionPriority(t *testing.T) {
+	testCases := map[string]struct {
+		priority  string
+		eventType model.HookEvent
+		expected  int
+	}{
+		"check success is low":        {eventType: model.HookCheckSuccess, expected: 2},
+		"complete failure is high":    {eventType: model.HookCompleteFailure, expected: 4},
+		"complete success is default": {eventType: model.HookCompleteSuccess, expected: 3},
+		"configured priority wins":    {priority: "urgent", eventType: model.HookCheckSuccess, expected: 5},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var received model.NtfyPayload
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gt.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+			}))
+			defer server.Close()
+
+			data := map[string]interface{}{
+				"server":  server.URL,
+				"topic":   "ci",
+				"message": "done",
+			}
+			if tc.priority != "" {
+				data["priority"] = tc.priority
+			}
+
+			action := model.Action{Type: "ntfy", Data: data}
+			gt.NoError(t, usecase.NewNtfyAction().Execute(context.Background(), action, model.WorkflowEvent{Type: tc.eventType})).Required()
+			gt.Equal(t, received.Priority, tc.expected)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"maps"
+	"sync"
+
+	"github.com/m-mizutani/goerr/v2"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+)
+
+// validatePipeline checks that action IDs are unique and that needs refer to
+// earlier actions of the event, which also rules out cycles
+func validatePipeline(actions []model.Action) error {
+	seen := make(map[string]bool)
+	for i, action := range actions {
+		for _, need := range action.Needs {
+			if !seen[need] {
+				return goerr.New("action needs an unknown or later action",
+					goerr.V("index", i), goerr.V("needs", need))
+			}
+		}
+		if action.ID == "" {
+			continue
+		}
+		if seen[action.ID] {
+			return goerr.New("duplicate action id", goerr.V("index", i), goerr.V("id", action.ID))
+		}
+		seen[action.ID] = true
+	}
+	return nil
+}
+
+// actionDependencies returns the indexes of the actions each action waits for.
+// In sequential mode every action also waits for the one listed before it.
+func actionDependencies(actions []model.Action, sequential bool) ([][]int, error) {
+	if err := validatePipeline(actions); err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]int)
+	deps := make([][]int, len(actions))
+	for i, action := range actions {
+		if sequential && i > 0 {
+			deps[i] = append(deps[i], i-1)
+		}
+		for _, need := range action.Needs {
+			deps[i] = append(deps[i], ids[need])
+		}
+		if action.ID != "" {
+			ids[action.ID] = i
+		}
+	}
+	return deps, nil
+}
+
+// pipeline tracks the actions of one event while they run
+type pipeline struct {
+	done []chan struct{}
+
+	mu      sync.Mutex
+	failed  []bool
+	outputs map[string]map[string]string
+}
+
+func newPipeline(size int) *pipeline {
+	p := &pipeline{
+		done:    make([]chan struct{}, size),
+		failed:  make([]bool, size),
+		outputs: make(map[string]map[string]string),
+	}
+	for i := range p.done {
+		p.done[i] = make(chan struct{})
+	}
+	return p
+}
+
+// wait blocks until the dependencies finish and reports whether all of them succeeded
+func (p *pipeline) wait(deps []int) bool {
+	for _, dep := range deps {
+		<-p.done[dep]
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, dep := range deps {
+		if p.failed[dep] {
+			return false
+		}
+	}
+	return true
+}
+
+// finish marks the action as done; failed actions make their dependents skip
+func (p *pipeline) finish(idx int, failed bool) {
+	p.mu.Lock()
+	p.failed[idx] = failed
+	p.mu.Unlock()
+	close(p.done[idx])
+}
+
+func (p *pipeline) setOutputs(id string, outputs map[string]string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.outputs[id] = outputs
+}
+
+// snapshot returns the outputs of the actions finished so far
+func (p *pipeline) snapshot() map[string]map[string]string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.outputs) == 0 {
+		return nil
+	}
+	return maps.Clone(p.outputs)
+}
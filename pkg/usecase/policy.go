@@ -0,0 +1,254 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/m-mizutani/ctxlog"
+	"github.com/m-mizutani/goerr/v2"
+	"github.com/m-mizutani/octap/pkg/domain/interfaces"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+)
+
+// actionPolicy decides per action whether it runs, by quiet hours and rate limits
+type actionPolicy struct {
+	quietHours model.QuietHours
+	rateLimit  model.RateLimit
+	now        func() time.Time
+
+	mu   sync.Mutex
+	sent map[string][]time.Time // Start times of each action within the rate limit window
+}
+
+func newActionPolicy(policy model.NotificationPolicy) *actionPolicy {
+	return &actionPolicy{
+		quietHours: policy.QuietHours,
+		rateLimit:  policy.RateLimit,
+		now:        time.Now,
+		sent:       make(map[string][]time.Time),
+	}
+}
+
+// suppress returns why the action must not run, or "" if it may run. An action
+// that runs counts toward its rate limit.
+func (p *actionPolicy) suppress(action model.Action, idx int, event model.WorkflowEvent) string {
+	if p == nil {
+		return ""
+	}
+
+	now := p.now()
+	if action.Type == "sound" && p.quietHours.Enabled() && p.quietHours.Contains(now) {
+		return "quiet hours"
+	}
+
+	if !p.rateLimit.Enabled() {
+		return ""
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := fmt.Sprintf("%s/%d", event.Type, idx)
+	sent := slices.DeleteFunc(p.sent[key], func(t time.Time) bool {
+		return now.Sub(t) >= p.rateLimit.Per
+	})
+	if len(sent) >= p.rateLimit.Count {
+		p.sent[key] = sent
+		return "rate limit"
+	}
+	p.sent[key] = append(sent, now)
+	return ""
+}
+
+// pendingEvent is a check event held back by the digest window
+type pendingEvent struct {
+	ctx   context.Context
+	event model.WorkflowEvent
+}
+
+// policyHookExecutor applies the event level notification policy, dedup and
+// digests, before passing events to a hookExecutor
+type policyHookExecutor struct {
+	inner  *hookExecutor
+	policy model.NotificationPolicy
+	locks  *lockStore
+
+	// mu is held while a digest is flushed so that WaitForCompletion does not
+	// return before the collapsed events are passed to inner
+	mu      sync.Mutex
+	pending map[model.HookEvent][]pendingEvent
+	timer   *time.Timer
+}
+
+// NewPolicyHookExecutor creates a HookExecutor that applies the notification
+// policy of the config. Dedup locks are kept under dir/locks; an empty dir
+// selects the default ~/.config/octap directory.
+func NewPolicyHookExecutor(config *model.Config, dir string) interfaces.HookExecutor {
+	inner := NewHookExecutor(config).(*hookExecutor)
+	if config == nil {
+		return inner
+	}
+
+	// Without a lock directory, dedup fails open and every instance fires
+	locksDir, err := dataPath(dir, "locks")
+	locks := newLockStore(locksDir)
+	if err != nil {
+		locks.err = goerr.Wrap(err, "dedup locks are not available")
+	}
+
+	inner.policy = newActionPolicy(config.Hooks.Policy)
+	return &policyHookExecutor{
+		inner:   inner,
+		policy:  config.Hooks.Policy,
+		locks:   locks,
+		pending: make(map[model.HookEvent][]pendingEvent),
+	}
+}
+
+// Execute runs hooks for the given event unless another instance already did.
+// Check events are held back for the digest window.
+func (p *policyHookExecutor) Execute(ctx context.Context, event model.WorkflowEvent) error {
+	logger := ctxlog.From(ctx)
+
+	if p.policy.Dedup {
+		acquired, err := p.acquire(event)
+		if err != nil {
+			// Firing twice is better than not firing at all
+			logger.Warn("Failed to acquire hook lock",
+				slog.String("event", string(event.Type)),
+				slog.String("error", err.Error()),
+			)
+		} else if !acquired {
+			logger.Debug("Skipping event fired by another octap instance",
+				slog.String("event", string(event.Type)),
+				slog.String("workflow", event.Workflow),
+			)
+			return nil
+		}
+	}
+
+	if p.policy.DigestWindow > 0 {
+		if event.Type.IsCheck() {
+			p.hold(ctx, event)
+			return nil
+		}
+		// The digest reports runs that finished before the commit did
+		if event.Type.IsSummary() {
+			p.flush()
+		}
+	}
+
+	return p.inner.Execute(ctx, event)
+}
+
+// acquire takes the dedup lock of the event and reports whether this instance fires it
+func (p *policyHookExecutor) acquire(event model.WorkflowEvent) (bool, error) {
+	if event.Commit.SHA == "" {
+		return true, nil
+	}
+
+	// A re-attempt of a run is a new event
+	var attempt int
+	if event.RunID != 0 {
+		attempt = eventRun(event).RunAttempt
+	}
+	key := sha256.Sum256(fmt.Appendf(nil, "%s|%s|%s|%d|%d", event.Repository, event.Commit.SHA, event.Type, event.RunID, attempt))
+	return p.locks.acquire(hex.EncodeToString(key[:]))
+}
+
+// hold buffers a check event and starts the digest window with the first one
+func (p *policyHookExecutor) hold(ctx context.Context, event model.WorkflowEvent) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// The monitor's context may be canceled before the window ends
+	p.pending[event.Type] = append(p.pending[event.Type], pendingEvent{
+		ctx:   context.WithoutCancel(ctx),
+		event: event,
+	})
+	if p.timer == nil {
+		p.timer = time.AfterFunc(p.policy.DigestWindow, p.flush)
+	}
+}
+
+// flush passes the held events to inner, collapsed into one event per event type
+func (p *policyHookExecutor) flush() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.timer != nil {
+		p.timer.Stop()
+		p.timer = nil
+	}
+
+	for _, eventType := range model.HookEvents {
+		pending := p.pending[eventType]
+		if len(pending) == 0 {
+			continue
+		}
+		last := pending[len(pending)-1]
+		event := digestEvent(pending)
+		if err := p.inner.Execute(last.ctx, event); err != nil {
+			ctxlog.From(last.ctx).Warn("Failed to execute digest hooks",
+				slog.String("event", string(eventType)),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+	clear(p.pending)
+}
+
+// digestEvent collapses events of the same type into the latest one. Its
+// Workflow lists the names of all runs and Digest holds the runs.
+func digestEvent(pending []pendingEvent) model.WorkflowEvent {
+	event := pending[len(pending)-1].event
+	if len(pending) == 1 {
+		return event
+	}
+
+	names := make([]string, 0, len(pending))
+	digest := make([]*model.WorkflowRun, 0, len(pending))
+	for _, p := range pending {
+		names = append(names, p.event.Workflow)
+		digest = append(digest, eventRun(p.event))
+	}
+	event.Workflow = strings.Join(names, ", ")
+	event.Digest = digest
+	return event
+}
+
+// eventRun returns the run that fired the event
+func eventRun(event model.WorkflowEvent) *model.WorkflowRun {
+	for _, run := range event.Runs {
+		if run.ID == event.RunID {
+			return run
+		}
+	}
+	return &model.WorkflowRun{
+		ID:         event.RunID,
+		Name:       event.Workflow,
+		Repository: event.Repository,
+		URL:        event.URL,
+	}
+}
+
+// WaitForCompletion fires the events held for the digest, waits for all pending
+// actions and releases the dedup locks
+func (p *policyHookExecutor) WaitForCompletion() {
+	p.flush()
+	p.inner.WaitForCompletion()
+	// Later instances on the commit fire their events again
+	p.locks.release()
+}
+
+// Results returns the outcome of every action finished so far
+func (p *policyHookExecutor) Results() model.HookResults {
+	return p.inner.Results()
+}
@@ -0,0 +1,248 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/m-mizutani/gt"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+	"github.com/m-mizutani/octap/pkg/usecase"
+)
+
+// noopAction succeeds without side effects
+var noopAction = model.Action{Type: "command", Data: map[string]any{"command": "true"}}
+
+func checkEvent(eventType model.HookEvent, runID int64, name string) model.WorkflowEvent {
+	run := &model.WorkflowRun{ID: runID, Name: name, HeadSHA: "abc1234", Repository: "owner/repo", RunAttempt: 1}
+	return model.WorkflowEvent{
+		Type:       eventType,
+		Repository: "owner/repo",
+		Workflow:   name,
+		RunID:      runID,
+		Runs:       []*model.WorkflowRun{run},
+		Commit:     model.Commit{SHA: "abc1234"},
+	}
+}
+
+func TestPolicyRateLimit(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses shell commands")
+	}
+
+	executor := usecase.NewPolicyHookExecutor(&model.Config{Hooks: model.HooksConfig{
+		Policy: model.NotificationPolicy{
+			RateLimit: model.RateLimit{Count: 2, Per: time.Hour},
+		},
+		CheckFailure: []model.Action{noopAction, noopAction},
+	}}, t.TempDir())
+
+	for i := range 3 {
+		gt.NoError(t, executor.Execute(context.Background(), checkEvent(model.HookCheckFailure, int64(i+1), "CI"))).Required()
+	}
+	executor.WaitForCompletion()
+
+	results := executor.Results()
+	gt.A(t, results).Length(6).Required()
+	// Each action is limited on its own
+	for _, idx := range []int{0, 1} {
+		var skipped int
+		for _, result := range results {
+			if result.Index == idx && result.Status == model.HookStatusSkipped {
+				skipped++
+			}
+		}
+		gt.Equal(t, skipped, 1)
+	}
+}
+
+func TestPolicyQuietHours(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses shell commands")
+	}
+
+	now := time.Now()
+	quiet := model.QuietHours{
+		Start: now.Add(-time.Hour).Format("15:04"),
+		End:   now.Add(time.Hour).Format("15:04"),
+	}
+	executor := usecase.NewPolicyHookExecutor(&model.Config{Hooks: model.HooksConfig{
+		Policy: model.NotificationPolicy{QuietHours: quiet},
+		CompleteSuccess: []model.Action{
+			{Type: "sound", Data: map[string]any{"path": "/nonexistent.aiff"}},
+			noopAction,
+		},
+	}}, t.TempDir())
+
+	gt.NoError(t, executor.Execute(context.Background(), checkEvent(model.HookCompleteSuccess, 0, ""))).Required()
+	executor.WaitForCompletion()
+
+	results := executor.Results()
+	gt.A(t, results).Length(2).Required()
+	for _, result := range results {
+		if result.Type == "sound" {
+			gt.Equal(t, result.Status, model.HookStatusSkipped)
+		} else {
+			gt.Equal(t, result.Status, model.HookStatusSuccess)
+		}
+	}
+}
+
+func TestPolicyDigest(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses shell commands")
+	}
+
+	executor := usecase.NewPolicyHookExecutor(&model.Config{Hooks: model.HooksConfig{
+		Policy: model.NotificationPolicy{DigestWindow: time.Hour},
+		CheckFailure: []model.Action{{
+			Type: "command",
+			When: `len(digest) == 3`,
+			Data: map[string]any{"command": "true"},
+		}},
+		CheckSuccess:    []model.Action{noopAction},
+		CompleteFailure: []model.Action{noopAction},
+	}}, t.TempDir())
+
+	ctx := context.Background()
+	gt.NoError(t, executor.Execute(ctx, checkEvent(model.HookCheckFailure, 1, "build"))).Required()
+	gt.NoError(t, executor.Execute(ctx, checkEvent(model.HookCheckSuccess, 2, "lint"))).Required()
+	gt.NoError(t, executor.Execute(ctx, checkEvent(model.HookCheckFailure, 3, "test"))).Required()
+	gt.NoError(t, executor.Execute(ctx, checkEvent(model.HookCheckFailure, 4, "e2e"))).Required()
+	gt.A(t, executor.Results()).Length(0)
+
+	// The summary event fires the digest first
+	gt.NoError(t, executor.Execute(ctx, checkEvent(model.HookCompleteFailure, 0, ""))).Required()
+	executor.WaitForCompletion()
+
+	results := executor.Results()
+	gt.A(t, results).Length(3).Required()
+	for _, result := range results {
+		gt.Equal(t, result.Status, model.HookStatusSuccess)
+		switch result.Event {
+		case model.HookCheckFailure:
+			gt.Equal(t, result.Workflow, "build, test, e2e")
+		case model.HookCheckSuccess:
+			gt.Equal(t, result.Workflow, "lint")
+		}
+	}
+}
+
+func TestPolicyDigestWindow(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses shell commands")
+	}
+
+	executor := usecase.NewPolicyHookExecutor(&model.Config{Hooks: model.HooksConfig{
+		Policy:       model.NotificationPolicy{DigestWindow: 10 * time.Millisecond},
+		CheckFailure: []model.Action{noopAction},
+	}}, t.TempDir())
+
+	ctx := context.Background()
+	gt.NoError(t, executor.Execute(ctx, checkEvent(model.HookCheckFailure, 1, "build"))).Required()
+	gt.NoError(t, executor.Execute(ctx, checkEvent(model.HookCheckFailure, 2, "test"))).Required()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(executor.Results()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	executor.WaitForCompletion()
+
+	results := executor.Results()
+	gt.A(t, results).Length(1).Required()
+	gt.Equal(t, results[0].Workflow, "build, test")
+}
+
+func TestPolicyDedup(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses shell commands")
+	}
+
+	dir := t.TempDir()
+	config := &model.Config{Hooks: model.HooksConfig{
+		Policy:       model.NotificationPolicy{Dedup: true},
+		CheckFailure: []model.Action{noopAction},
+	}}
+	first := usecase.NewPolicyHookExecutor(config, dir)
+	second := usecase.NewPolicyHookExecutor(config, dir)
+
+	ctx := context.Background()
+	gt.NoError(t, first.Execute(ctx, checkEvent(model.HookCheckFailure, 1, "build"))).Required()
+	gt.NoError(t, second.Execute(ctx, checkEvent(model.HookCheckFailure, 1, "build"))).Required()
+	// Another run of the commit is a different event
+	gt.NoError(t, second.Execute(ctx, checkEvent(model.HookCheckFailure, 2, "test"))).Required()
+	first.WaitForCompletion()
+	second.WaitForCompletion()
+
+	gt.A(t, first.Results()).Length(1)
+	results := second.Results()
+	gt.A(t, results).Length(1).Required()
+	gt.Equal(t, results[0].Workflow, "test")
+}
+
+func TestPolicyDedupSequentialInstances(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses shell commands")
+	}
+
+	dir := t.TempDir()
+	config := &model.Config{Hooks: model.HooksConfig{
+		Policy:          model.NotificationPolicy{Dedup: true},
+		CheckFailure:    []model.Action{noopAction},
+		CompleteFailure: []model.Action{noopAction},
+	}}
+	ctx := context.Background()
+
+	first := usecase.NewPolicyHookExecutor(config, dir)
+	gt.NoError(t, first.Execute(ctx, checkEvent(model.HookCheckFailure, 1, "build"))).Required()
+	gt.NoError(t, first.Execute(ctx, checkEvent(model.HookCompleteFailure, 0, ""))).Required()
+	first.WaitForCompletion()
+	gt.A(t, first.Results()).Length(2)
+
+	// The locks are gone with the instance that held them
+	entries, err := os.ReadDir(filepath.Join(dir, "locks"))
+	gt.NoError(t, err)
+	gt.A(t, entries).Length(0)
+
+	// The failed run is re-attempted and the commit is monitored again
+	retried := checkEvent(model.HookCheckFailure, 1, "build")
+	retried.Runs[0].RunAttempt = 2
+	second := usecase.NewPolicyHookExecutor(config, dir)
+	gt.NoError(t, second.Execute(ctx, retried)).Required()
+	gt.NoError(t, second.Execute(ctx, checkEvent(model.HookCompleteFailure, 0, ""))).Required()
+	second.WaitForCompletion()
+	gt.A(t, second.Results()).Length(2)
+}
+
+func TestPolicyDedupStaleLock(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses shell commands")
+	}
+
+	dir := t.TempDir()
+	locks := filepath.Join(dir, "locks")
+	gt.NoError(t, os.MkdirAll(locks, 0700)).Required()
+
+	// A process that has exited left its locks behind
+	cmd := exec.Command(os.Args[0], "-test.run=^$")
+	gt.NoError(t, cmd.Run()).Required()
+	pid := []byte(fmt.Sprintf("%d\n", cmd.Process.Pid))
+	stale := filepath.Join(locks, "unrelated.lock")
+	gt.NoError(t, os.WriteFile(stale, pid, 0600)).Required()
+
+	executor := usecase.NewPolicyHookExecutor(&model.Config{Hooks: model.HooksConfig{
+		Policy:       model.NotificationPolicy{Dedup: true},
+		CheckFailure: []model.Action{noopAction},
+	}}, dir)
+	gt.NoError(t, executor.Execute(context.Background(), checkEvent(model.HookCheckFailure, 1, "build"))).Required()
+	executor.WaitForCompletion()
+
+	gt.A(t, executor.Results()).Length(1)
+	_, err := os.Stat(stale)
+	gt.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"github.com/m-mizutani/octap/pkg/domain/model"
+)
+
+// pushPriority returns the configured priority, or one chosen from the event
+// type so that failures stand out and successes stay quiet
+func pushPriority(priority model.PushPriority, eventType model.HookEvent) model.PushPriority {
+	if priority != "" {
+		return priority
+	}
+
+	switch eventType {
+	case model.HookCheckFailure, model.HookCompleteFailure, model.HookFirstFailure, model.HookCheckTimedOut:
+		return model.PushPriorityHigh
+	case model.HookCheckSuccess, model.HookCheckSkipped, model.HookRunStarted:
+		return model.PushPriorityLow
+	default:
+		return model.PushPriorityDefault
+	}
+}
+
+// renderPushMessage renders the title and message templates shared by push actions
+func renderPushMessage(title, message string, event model.WorkflowEvent) (string, string, error) {
+	renderedTitle, err := renderTemplate("title", title, event)
+	if err != nil {
+		return "", "", err
+	}
+	renderedMessage, err := renderTemplate("message", message, event)
+	if err != nil {
+		return "", "", err
+	}
+	return renderedTitle, renderedMessage, nil
+}
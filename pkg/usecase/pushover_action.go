@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/m-mizutani/ctxlog"
+	"github.com/m-mizutani/goerr/v2"
+	"github.com/m-mizutani/octap/pkg/domain/interfaces"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+)
+
+const (
+	pushoverEndpoint = "https://api.pushover.net/1/messages.json"
+
+	// Emergency messages repeat every pushoverEmergencyRetry until acknowledged
+	// or pushoverEmergencyExpire has passed
+	pushoverEmergencyRetry  = 60 * time.Second
+	pushoverEmergencyExpire = time.Hour
+)
+
+// pushoverPriorities maps push priorities to Pushover's -2 to 2 scale
+var pushoverPriorities = map[model.PushPriority]int{
+	model.PushPriorityMin:     -2,
+	model.PushPriorityLow:     -1,
+	model.PushPriorityDefault: 0,
+	model.PushPriorityHigh:    1,
+	model.PushPriorityUrgent:  2,
+}
+
+type pushoverAction struct {
+	httpClient *http.Client
+	endpoint   string
+}
+
+// NewPushoverAction creates a new PushoverAction instance
+func NewPushoverAction() interfaces.ActionExecutor {
+	return &pushoverAction{
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		endpoint: pushoverEndpoint,
+	}
+}
+
+// Execute sends a message through the Pushover API
+func (p *pushoverAction) Execute(ctx context.Context, action model.Action, event model.WorkflowEvent) error {
+	logger := ctxlog.From(ctx)
+
+	logger.Debug("pushoverAction.Execute called",
+		slog.String("event_type", string(event.Type)),
+	)
+
+	pushoverAction, err := action.ToPushoverAction()
+	if err != nil {
+		logger.Error("Failed to parse pushover action",
+			slog.String("error", err.Error()),
+		)
+		return goerr.Wrap(err, "failed to parse pushover action")
+	}
+
+	payload, err := p.buildPayload(pushoverAction, event)
+	if err != nil {
+		return err
+	}
+	if payload.Token == "" || payload.User == "" {
+		return goerr.New("pushover token or user is empty after expansion")
+	}
+
+	err = postJSON(ctx, p.httpClient, p.endpoint, payload)
+	if err != nil {
+		return goerr.Wrap(err, "failed to send pushover notification")
+	}
+
+	logger.Debug("Pushover notification sent successfully")
+	return nil
+}
+
+// buildPayload renders the templates into a Pushover message
+func (p *pushoverAction) buildPayload(action *model.PushoverAction, event model.WorkflowEvent) (*model.PushoverPayload, error) {
+	title, message, err := renderPushMessage(action.Title, action.Message, event)
+	if err != nil {
+		return nil, goerr.Wrap(err, "failed to build pushover message")
+	}
+
+	payload := &model.PushoverPayload{
+		Token:    expandEnvVars(action.Token),
+		User:     expandEnvVars(action.User),
+		Device:   action.Device,
+		Sound:    action.Sound,
+		Title:    title,
+		Message:  message,
+		Priority: pushoverPriorities[pushPriority(action.Priority, event.Type)],
+	}
+	if event.URL != "" {
+		payload.URL = event.URL
+		payload.URLTitle = "Open run"
+	}
+	if payload.Priority == pushoverPriorities[model.PushPriorityUrgent] {
+		payload.Retry = int(pushoverEmergencyRetry.Seconds())
+		payload.Expire = int(pushoverEmergencyExpire.Seconds())
+	}
+
+	return payload, nil
+}
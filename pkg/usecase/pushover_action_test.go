@@ -0,0 +1,68 @@
+package usecase_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/m-mizutani/gt"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+	"github.com/m-mizutani/octap/pkg/usecase"
+)
+
+func TestPushoverAction(t *testing.T) {
+	var received model.PushoverPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gt.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+
+	t.Setenv("OCTAP_TEST_PUSHOVER_USER", "user-key")
+	action := model.Action{
+		Type: "pushover",
+		Data: map[string]interface{}{
+			"token":   "app-token",
+			"user":    "${OCTAP_TEST_PUSHOVER_USER}",
+			"message": "{{.Workflow}} failed",
+		},
+	}
+	event := model.WorkflowEvent{
+		Type:     model.HookCheckFailure,
+		Workflow: "build",
+		URL:      "https://github.com/owner/repo/actions/runs/1",
+	}
+
+	gt.NoError(t, usecase.NewPushoverActionWithEndpoint(server.URL).Execute(context.Background(), action, event)).Required()
+	gt.Equal(t, received.Token, "app-token")
+	gt.Equal(t, received.User, "user-key")
+	gt.Equal(t, received.Message, "build failed")
+	gt.Equal(t, received.Priority, 1)
+	gt.Equal(t, received.URL, event.URL)
+	gt.Equal(t, received.Retry, 0)
+}
+
+func TestPushoverActionEmergency(t *testing.T) {
+	var received model.PushoverPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gt.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+
+	action := model.Action{
+		Type: "pushover",
+		Data: map[string]interface{}{
+			"token":    "app-token",
+			"user":     "user-key",
+			"message":  "failed",
+			"priority": "urgent",
+		},
+	}
+
+	gt.NoError(t, usecase.NewPushoverActionWithEndpoint(server.URL).Execute(context.Background(), action, model.WorkflowEvent{Type: model.HookCompleteFailure})).Required()
+	gt.Equal(t, received.Priority, 2)
+	// Emergency messages are rejected by Pushover without retry and expire
+	gt.Equal(t, received.Retry, 60)
+	gt.Equal(t, received.Expire, 3600)
+}
@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+
+	"github.com/m-mizutani/ctxlog"
+	"github.com/m-mizutani/goerr/v2"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+)
+
+// defaultRetryPolicy is used by actions in retriedByDefault that don't configure retries
+var defaultRetryPolicy = model.RetryPolicy{
+	Attempts: 3,
+	Backoff:  time.Second,
+}
+
+// maxBackoff caps the wait between attempts however many retries are configured
+const maxBackoff = time.Minute
+
+// retriedByDefault are the action types that send HTTP requests, whose failures
+// are often transient. Other actions run once unless they configure retries.
+var retriedByDefault = map[string]bool{
+	"slack":    true,
+	"webhook":  true,
+	"discord":  true,
+	"teams":    true,
+	"ntfy":     true,
+	"gotify":   true,
+	"pushover": true,
+}
+
+// defaultTimeouts limit each attempt of actions that don't configure a timeout
+// and have no other limit, such as an HTTP client timeout
+var defaultTimeouts = map[string]time.Duration{
+	"command": 30 * time.Second,
+	"webhook": 30 * time.Second,
+}
+
+// actionTimeout returns the timeout of each attempt of the action with defaults filled in
+func actionTimeout(action model.Action) (time.Duration, error) {
+	timeout, err := action.Timeout()
+	if err != nil {
+		return 0, err
+	}
+	if timeout == 0 {
+		timeout = defaultTimeouts[action.Type]
+	}
+	return timeout, nil
+}
+
+// actionRetryPolicy returns the retry policy of the action with defaults filled in
+func actionRetryPolicy(action model.Action) (model.RetryPolicy, error) {
+	policy, err := action.RetryPolicy()
+	if err != nil {
+		return model.RetryPolicy{}, err
+	}
+
+	if policy.Attempts == 0 {
+		policy.Attempts = 1
+		if retriedByDefault[action.Type] {
+			policy.Attempts = defaultRetryPolicy.Attempts
+		}
+	}
+	if policy.Backoff == 0 {
+		policy.Backoff = defaultRetryPolicy.Backoff
+	}
+	return policy, nil
+}
+
+// retryable reports whether the failed attempt may succeed if made again
+func retryable(err error) bool {
+	var permErr *permanentError
+	if errors.As(err, &permErr) {
+		return false
+	}
+	var statusErr *httpStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.retryable()
+	}
+	return true
+}
+
+// withRetry calls attempt until it succeeds, fails with an error that is not
+// retryable, or the policy's attempts are used up. Each attempt is limited by
+// timeout if it is positive. The backoff doubles after each retry and is jittered
+// so that instances failing together do not retry together.
+func withRetry(ctx context.Context, policy model.RetryPolicy, timeout time.Duration, attempt func(ctx context.Context) error) error {
+	logger := ctxlog.From(ctx)
+
+	backoff := policy.Backoff
+	for n := 1; ; n++ {
+		err := runAttempt(ctx, timeout, attempt)
+		if err == nil {
+			return nil
+		}
+		if !retryable(err) || n >= policy.Attempts || ctx.Err() != nil {
+			return err
+		}
+
+		wait := jitter(backoff)
+		logger.Warn("Action failed, retrying",
+			slog.Int("attempt", n),
+			slog.Duration("backoff", wait),
+			slog.String("error", err.Error()),
+		)
+		select {
+		case <-ctx.Done():
+			return goerr.Wrap(ctx.Err(), "retry canceled")
+		case <-time.After(wait):
+		}
+		countRetry(ctx)
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
+// runAttempt calls attempt with the timeout applied to its context
+func runAttempt(ctx context.Context, timeout time.Duration, attempt func(ctx context.Context) error) error {
+	if timeout <= 0 {
+		return attempt(ctx)
+	}
+
+	attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err := attempt(attemptCtx)
+	if err != nil && ctx.Err() == nil && errors.Is(attemptCtx.Err(), context.DeadlineExceeded) {
+		return goerr.Wrap(err, fmt.Sprintf("action timed out after %s", timeout))
+	}
+	return err
+}
+
+// jitter returns a random duration between half of d and d
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + rand.N(d/2+1)
+}
@@ -0,0 +1,223 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/m-mizutani/gt"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+	"github.com/m-mizutani/octap/pkg/usecase"
+)
+
+func TestWithRetry(t *testing.T) {
+	policy := model.RetryPolicy{Attempts: 3, Backoff: time.Millisecond}
+
+	testCases := map[string]struct {
+		err   error
+		calls int
+	}{
+		"retries server errors":          {err: usecase.NewHTTPStatusError(http.StatusServiceUnavailable), calls: 3},
+		"retries rate limits":            {err: usecase.NewHTTPStatusError(http.StatusTooManyRequests), calls: 3},
+		"does not retry client errors":   {err: usecase.NewHTTPStatusError(http.StatusBadRequest), calls: 1},
+		"does not retry permanent error": {err: usecase.NewPermanentError(errors.New("channel_not_found")), calls: 1},
+		"retries other errors":           {err: errors.New("connection refused"), calls: 3},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var calls int
+			err := usecase.WithRetry(context.Background(), policy, 0, func(ctx context.Context) error {
+				calls++
+				return fmt.Errorf("failed to send: %w", tc.err)
+			})
+			gt.Error(t, err)
+			gt.Equal(t, calls, tc.calls)
+		})
+	}
+
+	t.Run("stops at the first success", func(t *testing.T) {
+		var calls int
+		err := usecase.WithRetry(context.Background(), policy, 0, func(ctx context.Context) error {
+			calls++
+			if calls < 2 {
+				return errors.New("temporary")
+			}
+			return nil
+		})
+		gt.NoError(t, err)
+		gt.Equal(t, calls, 2)
+	})
+
+	t.Run("stops when the context is canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		var calls int
+		err := usecase.WithRetry(ctx, model.RetryPolicy{Attempts: 5, Backoff: time.Hour}, 0, func(ctx context.Context) error {
+			calls++
+			cancel()
+			return errors.New("temporary")
+		})
+		gt.Error(t, err)
+		gt.Equal(t, calls, 1)
+	})
+
+	t.Run("limits each attempt by the timeout", func(t *testing.T) {
+		var calls int
+		err := usecase.WithRetry(context.Background(), policy, 10*time.Millisecond, func(ctx context.Context) error {
+			calls++
+			if calls < 3 {
+				<-ctx.Done()
+				return ctx.Err()
+			}
+			return nil
+		})
+		gt.NoError(t, err)
+		gt.Equal(t, calls, 3)
+	})
+
+	t.Run("reports a timed out attempt", func(t *testing.T) {
+		err := usecase.WithRetry(context.Background(), model.RetryPolicy{Attempts: 1}, 10*time.Millisecond, func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		gt.Error(t, err)
+		gt.True(t, strings.Contains(err.Error(), "timed out after 10ms"))
+	})
+}
+
+func TestJitter(t *testing.T) {
+	gt.Equal(t, usecase.Jitter(0), 0)
+	for range 100 {
+		d := usecase.Jitter(time.Second)
+		gt.True(t, d >= 500*time.Millisecond && d <= time.Second)
+	}
+}
+
+func TestHookExecutorRetry(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses shell commands")
+	}
+
+	execute := func(t *testing.T, action model.Action) model.HookResult {
+		t.Helper()
+		executor := usecase.NewHookExecutor(&model.Config{Hooks: model.HooksConfig{
+			CompleteFailure: []model.Action{action},
+		}})
+		gt.NoError(t, executor.Execute(context.Background(), model.WorkflowEvent{Type: model.HookCompleteFailure})).Required()
+		results := executor.Results()
+		gt.A(t, results).Length(1).Required()
+		return results[0]
+	}
+
+	// failingServer responds with status until it has been called fails times
+	failingServer := func(t *testing.T, status int, fails int32) (*httptest.Server, *atomic.Int32) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) <= fails {
+				w.WriteHeader(status)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		t.Cleanup(server.Close)
+		return server, &calls
+	}
+
+	t.Run("HTTP actions retry by default", func(t *testing.T) {
+		server, calls := failingServer(t, http.StatusServiceUnavailable, 2)
+		result := execute(t, model.Action{Type: "webhook", Data: map[string]any{
+			"url":   server.URL,
+			"retry": map[string]any{"backoff": "1ms"},
+		}})
+		gt.Equal(t, result.Status, model.HookStatusSuccess)
+		gt.Equal(t, result.Retries, 2)
+		gt.Equal(t, calls.Load(), int32(3))
+	})
+
+	t.Run("slack retries rate limits", func(t *testing.T) {
+		server, calls := failingServer(t, http.StatusTooManyRequests, 1)
+		result := execute(t, model.Action{Type: "slack", Data: map[string]any{
+			"webhook_url": server.URL,
+			"message":     "failed",
+			"retry":       map[string]any{"attempts": 2, "backoff": "1ms"},
+		}})
+		gt.Equal(t, result.Status, model.HookStatusSuccess)
+		gt.Equal(t, result.Retries, 1)
+		gt.Equal(t, calls.Load(), int32(2))
+	})
+
+	t.Run("slack does not retry client errors", func(t *testing.T) {
+		server, calls := failingServer(t, http.StatusNotFound, 5)
+		result := execute(t, model.Action{Type: "slack", Data: map[string]any{
+			"webhook_url": server.URL,
+			"message":     "failed",
+			"retry":       map[string]any{"attempts": 3, "backoff": "1ms"},
+		}})
+		gt.Equal(t, result.Status, model.HookStatusFailed)
+		gt.Equal(t, result.Retries, 0)
+		gt.Equal(t, calls.Load(), int32(1))
+	})
+
+	t.Run("commands run once by default", func(t *testing.T) {
+		result := execute(t, model.Action{Type: "command", Data: map[string]any{"command": "false"}})
+		gt.Equal(t, result.Status, model.HookStatusFailed)
+		gt.Equal(t, result.Retries, 0)
+	})
+
+	t.Run("commands retry when configured", func(t *testing.T) {
+		marker := filepath.Join(t.TempDir(), "failed-once")
+		result := execute(t, model.Action{Type: "command", Data: map[string]any{
+			"command": "sh",
+			"args":    []string{"-c", fmt.Sprintf("test -f %s || { touch %s; exit 1; }", marker, marker)},
+			"retry":   map[string]any{"attempts": 2, "backoff": "1ms"},
+		}})
+		gt.Equal(t, result.Status, model.HookStatusSuccess)
+		gt.Equal(t, result.Retries, 1)
+	})
+
+	t.Run("timed out attempts are retried", func(t *testing.T) {
+		marker := filepath.Join(t.TempDir(), "slow-once")
+		result := execute(t, model.Action{Type: "command", Data: map[string]any{
+			"command": "sh",
+			"args":    []string{"-c", fmt.Sprintf("test -f %s || { touch %s; exec sleep 5; }", marker, marker)},
+			"timeout": "200ms",
+			"retry":   map[string]any{"attempts": 2, "backoff": "1ms"},
+		}})
+		gt.Equal(t, result.Status, model.HookStatusSuccess)
+		gt.Equal(t, result.Retries, 1)
+	})
+
+	t.Run("timeout applies to HTTP actions", func(t *testing.T) {
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+		}))
+		defer server.Close()
+		defer close(release)
+
+		result := execute(t, model.Action{Type: "discord", Data: map[string]any{
+			"webhook_url": server.URL,
+			"message":     "failed",
+			"timeout":     "50ms",
+			"retry":       map[string]any{"attempts": 1},
+		}})
+		gt.Equal(t, result.Status, model.HookStatusFailed)
+		gt.True(t, strings.Contains(result.Error, "timed out after 50ms"))
+	})
+
+	t.Run("invalid retry fails the action", func(t *testing.T) {
+		result := execute(t, model.Action{Type: "command", Data: map[string]any{
+			"command": "true",
+			"retry":   "always",
+		}})
+		gt.Equal(t, result.Status, model.HookStatusFailed)
+		gt.True(t, strings.Contains(result.Error, "invalid retry policy"))
+	})
+}
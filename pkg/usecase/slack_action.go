@@ -1,7 +1,6 @@
 package usecase
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -9,7 +8,7 @@ import (
 	"net/http"
 	"os"
 	"strings"
-	"text/template"
+	"sync"
 	"time"
 
 	"github.com/m-mizutani/ctxlog"
@@ -18,8 +17,36 @@ import (
 	"github.com/m-mizutani/octap/pkg/domain/model"
 )
 
+// slackAPIURL is the base URL of the Slack Web API used in bot mode
+const slackAPIURL = "https://slack.com/api/"
+
 type slackAction struct {
 	httpClient *http.Client
+	apiURL     string
+
+	// threadsMu serializes bot mode posts so that concurrent events share one parent message
+	threadsMu sync.Mutex
+	// threads holds the parent message of each channel. octap monitors a single
+	// commit per process, so this is one parent per channel and monitoring session.
+	threads map[string]*slackThread
+}
+
+// slackThread is a bot mode parent message that is updated as workflow runs finish
+type slackThread struct {
+	// channel is the channel ID returned by chat.postMessage; chat.update does not accept names
+	channel    string
+	ts         string
+	repository string
+	runs       []slackThreadRun
+	// final is the complete or aborted event, empty while monitoring
+	final model.HookEvent
+}
+
+type slackThreadRun struct {
+	id   int64
+	name string
+	url  string
+	icon string
 }
 
 // NewSlackAction creates a new SlackAction instance
@@ -28,6 +55,8 @@ func NewSlackAction() interfaces.ActionExecutor {
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		apiURL:  slackAPIURL,
+		threads: make(map[string]*slackThread),
 	}
 }
 
@@ -49,8 +78,15 @@ func (s *slackAction) Execute(ctx context.Context, action model.Action, event mo
 		return goerr.Wrap(err, "failed to parse slack action")
 	}
 
+	if slackAction.BotMode() {
+		return s.executeBot(ctx, slackAction, event)
+	}
+
 	// Expand environment variables in webhook URL
-	webhookURL := expandEnvVars(slackAction.WebhookURL)
+	webhookURL, err := renderField("webhook_url", slackAction.WebhookURL, event)
+	if err != nil {
+		return goerr.Wrap(err, "failed to build webhook URL")
+	}
 	if webhookURL == "" {
 		return goerr.New("webhook URL is empty after expansion")
 	}
@@ -78,8 +114,15 @@ func (s *slackAction) Execute(ctx context.Context, action model.Action, event mo
 		payload.IconEmoji = slackAction.IconEmoji
 	}
 
-	// Add attachment with color if specified
-	if slackAction.Color != "" {
+	// Blocks replace the color attachment; the message stays as the notification text
+	if slackAction.Blocks != "" {
+		blocks, err := renderSlackBlocks(slackAction.Blocks, event)
+		if err != nil {
+			return goerr.Wrap(err, "failed to build slack blocks")
+		}
+		payload.Blocks = blocks
+	} else if slackAction.Color != "" {
+		// Add attachment with color if specified
 		payload.Attachments = []model.Attachment{
 			{
 				Color:     slackAction.Color,
@@ -92,79 +135,17 @@ func (s *slackAction) Execute(ctx context.Context, action model.Action, event mo
 		payload.Text = ""
 	}
 
-	// Send to Slack with retry
-	for attempt := 0; attempt < 3; attempt++ {
-		err = s.sendToSlack(ctx, webhookURL, payload)
-		if err == nil {
-			logger.Debug("Slack notification sent successfully",
-				slog.Int("attempt", attempt+1),
-			)
-			return nil
-		}
-
-		// Check if it's a rate limit error
-		if strings.Contains(err.Error(), "429") && attempt < 2 {
-			// Exponential backoff: 1s, 2s
-			backoff := time.Duration(1<<attempt) * time.Second
-			logger.Warn("Rate limited by Slack, retrying",
-				slog.Int("attempt", attempt+1),
-				slog.Duration("backoff", backoff),
-			)
-			time.Sleep(backoff)
-			continue
-		}
-
-		// For other errors, retry only once
-		if attempt == 0 && !strings.Contains(err.Error(), "4") {
-			logger.Warn("Failed to send Slack notification, retrying",
-				slog.Int("attempt", attempt+1),
-				slog.String("error", err.Error()),
-			)
-			time.Sleep(1 * time.Second)
-			continue
-		}
-
-		// Give up
-		break
+	if err := s.sendToSlack(ctx, webhookURL, payload); err != nil {
+		return goerr.Wrap(err, "failed to send slack notification")
 	}
 
-	logger.Error("Failed to send Slack notification after retries",
-		slog.String("error", err.Error()),
-	)
-	return goerr.Wrap(err, "failed to send slack notification")
+	logger.Debug("Slack notification sent successfully")
+	return nil
 }
 
 // buildMessage processes the message template
 func (s *slackAction) buildMessage(messageTemplate string, event model.WorkflowEvent) (string, error) {
-	// Prepare template data
-	data := struct {
-		Repository string
-		Workflow   string
-		RunID      int64
-		EventType  string
-		RunURL     string
-		Timestamp  time.Time
-	}{
-		Repository: event.Repository,
-		Workflow:   event.Workflow,
-		RunID:      event.RunID,
-		EventType:  string(event.Type),
-		RunURL:     event.URL,
-		Timestamp:  time.Now(),
-	}
-
-	// Parse and execute template
-	tmpl, err := template.New("message").Parse(messageTemplate)
-	if err != nil {
-		return "", goerr.Wrap(err, "failed to parse message template")
-	}
-
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, data); err != nil {
-		return "", goerr.Wrap(err, "failed to execute message template")
-	}
-
-	return buf.String(), nil
+	return renderTemplate("message", messageTemplate, event)
 }
 
 // sendToSlack sends the payload to Slack webhook
@@ -178,36 +159,213 @@ func (s *slackAction) sendToSlack(ctx context.Context, webhookURL string, payloa
 	}
 
 	// Log payload (mask webhook URL for security)
-	maskedURL := maskWebhookURL(webhookURL)
 	logger.Debug("Sending to Slack",
-		slog.String("webhook_url", maskedURL),
+		slog.String("webhook_url", maskWebhookURL(webhookURL)),
 		slog.String("payload", string(jsonData)),
 	)
 
-	// Create HTTP request
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return goerr.Wrap(err, "failed to create request")
+	headers := make(http.Header)
+	headers.Set("Content-Type", "application/json")
+	return sendRequest(ctx, s.httpClient, http.MethodPost, webhookURL, headers, jsonData)
+}
+
+// executeBot posts one parent message per channel and keeps it up to date.
+// Failures and the final result are posted as replies in its thread.
+func (s *slackAction) executeBot(ctx context.Context, action *model.SlackAction, event model.WorkflowEvent) error {
+	logger := ctxlog.From(ctx)
+
+	token := expandEnvVars(action.Token)
+	if token == "" {
+		return goerr.New("slack token is empty after expansion")
 	}
-	req.Header.Set("Content-Type", "application/json")
 
-	// Send request
-	resp, err := s.httpClient.Do(req)
+	message, err := s.buildMessage(action.Message, event)
 	if err != nil {
-		return goerr.Wrap(err, "failed to send request")
+		return goerr.Wrap(err, "failed to build message")
+	}
+	var blocks []byte
+	if action.Blocks != "" {
+		if blocks, err = renderSlackBlocks(action.Blocks, event); err != nil {
+			return goerr.Wrap(err, "failed to build slack blocks")
+		}
+	}
+
+	s.threadsMu.Lock()
+	defer s.threadsMu.Unlock()
+
+	thread, ok := s.threads[action.Channel]
+	if !ok {
+		thread = &slackThread{channel: action.Channel}
+		s.threads[action.Channel] = thread
+	}
+	thread.record(event)
+
+	parent := model.SlackMessage{
+		Channel:   thread.channel,
+		Text:      thread.text(),
+		UserName:  action.UserName,
+		IconEmoji: action.IconEmoji,
+	}
+	if thread.ts == "" {
+		resp, err := s.callAPI(ctx, token, "chat.postMessage", parent)
+		if err != nil {
+			return goerr.Wrap(err, "failed to post slack parent message", goerr.V("channel", action.Channel))
+		}
+		thread.channel = resp.Channel
+		thread.ts = resp.TS
+		logger.Debug("Posted slack parent message",
+			slog.String("channel", thread.channel),
+			slog.String("ts", thread.ts),
+		)
+	} else {
+		parent.TS = thread.ts
+		if _, err := s.callAPI(ctx, token, "chat.update", parent); err != nil {
+			return goerr.Wrap(err, "failed to update slack parent message", goerr.V("channel", action.Channel))
+		}
+	}
+
+	// Successful and skipped runs are only reflected in the parent to keep the channel quiet
+	if event.Type == model.HookCheckSuccess || event.Type == model.HookCheckSkipped {
+		return nil
 	}
-	defer resp.Body.Close()
 
-	// Check response
-	if resp.StatusCode != http.StatusOK {
-		var respBody bytes.Buffer
-		_, _ = respBody.ReadFrom(resp.Body) // Best effort to read response
-		return goerr.New(fmt.Sprintf("slack webhook returned status %d: %s", resp.StatusCode, respBody.String()))
+	reply := model.SlackMessage{
+		Channel:   thread.channel,
+		ThreadTS:  thread.ts,
+		Text:      message,
+		UserName:  action.UserName,
+		IconEmoji: action.IconEmoji,
+		Blocks:    blocks,
+	}
+	if len(blocks) == 0 && action.Color != "" {
+		reply.Attachments = []model.Attachment{
+			{
+				Color:     action.Color,
+				Text:      message,
+				Footer:    fmt.Sprintf("octap - %s", thread.repository),
+				Timestamp: time.Now().Unix(),
+			},
+		}
+		reply.Text = ""
+	}
+	if _, err := s.callAPI(ctx, token, "chat.postMessage", reply); err != nil {
+		return goerr.Wrap(err, "failed to post slack thread reply", goerr.V("channel", action.Channel))
 	}
 
+	logger.Debug("Slack thread reply posted", slog.String("event_type", string(event.Type)))
 	return nil
 }
 
+// callAPI calls a Slack Web API method
+func (s *slackAction) callAPI(ctx context.Context, token, method string, msg model.SlackMessage) (*model.SlackAPIResponse, error) {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return nil, goerr.Wrap(err, "failed to marshal slack message")
+	}
+
+	headers := make(http.Header)
+	headers.Set("Content-Type", "application/json; charset=utf-8")
+	headers.Set("Authorization", "Bearer "+token)
+	// A dry run has no response to decode, so the parent message gets a fake one
+	if out := dryRunOutput(ctx); out != nil {
+		writeDryRunRequest(out, http.MethodPost, s.apiURL+method, headers, body)
+		return &model.SlackAPIResponse{OK: true, Channel: msg.Channel, TS: "dry-run"}, nil
+	}
+
+	respBody, err := doRequest(ctx, s.httpClient, http.MethodPost, s.apiURL+method, headers, body)
+	if err != nil {
+		return nil, err
+	}
+	return decodeAPIResponse(method, respBody)
+}
+
+// decodeAPIResponse decodes a Web API response and fails if it reports an error
+func decodeAPIResponse(method string, respBody []byte) (*model.SlackAPIResponse, error) {
+	var apiResp model.SlackAPIResponse
+	if err := json.Unmarshal(respBody, &apiResp); err != nil {
+		return nil, goerr.Wrap(err, "failed to decode slack API response")
+	}
+	// The Web API reports errors such as channel_not_found with a 200 response
+	if !apiResp.OK {
+		return nil, &permanentError{goerr.New("slack API returned an error",
+			goerr.V("method", method),
+			goerr.V("error", apiResp.Error),
+		)}
+	}
+
+	return &apiResp, nil
+}
+
+// record updates the thread with the finished runs of a check event, which are
+// several for a digest, or the final result
+func (t *slackThread) record(event model.WorkflowEvent) {
+	if event.Repository != "" {
+		t.repository = event.Repository
+	}
+
+	switch {
+	case event.Type.IsSummary():
+		t.final = event.Type
+	case event.Type.IsCheck():
+		icon := emojiFor(event.Type)
+		if len(event.Digest) == 0 {
+			t.recordRun(slackThreadRun{id: event.RunID, name: event.Workflow, url: event.URL, icon: icon})
+			return
+		}
+		for _, run := range event.Digest {
+			t.recordRun(slackThreadRun{id: run.ID, name: run.Name, url: run.URL, icon: icon})
+		}
+	}
+}
+
+// recordRun adds a run to the list of the parent message
+func (t *slackThread) recordRun(run slackThreadRun) {
+	// A re-run reports the same run again; keep its position in the list
+	for i := range t.runs {
+		if t.runs[i].id == run.id {
+			t.runs[i] = run
+			return
+		}
+	}
+	t.runs = append(t.runs, run)
+}
+
+// text renders the parent message
+func (t *slackThread) text() string {
+	repository := t.repository
+	if repository == "" {
+		repository = "octap"
+	}
+	repository = slackEscape(repository)
+
+	var b strings.Builder
+	switch t.final {
+	case model.HookCompleteSuccess:
+		fmt.Fprintf(&b, "✅ *%s*: all workflows passed", repository)
+	case model.HookCompleteFailure:
+		fmt.Fprintf(&b, "❌ *%s*: workflows failed", repository)
+	case model.HookMonitorAborted:
+		fmt.Fprintf(&b, "⏹️ *%s*: monitoring stopped", repository)
+	default:
+		fmt.Fprintf(&b, "⏳ *%s*: workflows running", repository)
+	}
+
+	for _, run := range t.runs {
+		name := slackEscape(run.name)
+		if run.url != "" {
+			name = fmt.Sprintf("<%s|%s>", run.url, name)
+		}
+		fmt.Fprintf(&b, "\n%s %s", run.icon, name)
+	}
+
+	return b.String()
+}
+
+// slackEscape escapes the characters that Slack's mrkdwn treats as control characters
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
 // expandEnvVars expands environment variables in the string
 func expandEnvVars(s string) string {
 	// Support both ${VAR} and $VAR formats
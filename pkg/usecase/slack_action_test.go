@@ -3,9 +3,13 @@ package usecase_test
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/m-mizutani/gt"
 	"github.com/m-mizutani/octap/pkg/domain/model"
@@ -147,6 +151,13 @@ func TestSlackAction(t *testing.T) {
 					"webhook_url": "https://hooks.slack.com/test",
 				},
 			},
+			{
+				name: "token without channel",
+				data: map[string]interface{}{
+					"token":   "xoxb-test",
+					"message": "Test",
+				},
+			},
 			{
 				name: "empty webhook_url",
 				data: map[string]interface{}{
@@ -177,3 +188,201 @@ func TestSlackAction(t *testing.T) {
 		}
 	})
 }
+
+// slackAPICall is a request received by the fake Slack Web API
+type slackAPICall struct {
+	Method  string
+	Token   string
+	Message model.SlackMessage
+}
+
+func newFakeSlackAPI(t *testing.T, errorCode string) (*httptest.Server, func() []slackAPICall) {
+	var mu sync.Mutex
+	var calls []slackAPICall
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg model.SlackMessage
+		gt.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+
+		mu.Lock()
+		calls = append(calls, slackAPICall{
+			Method:  strings.TrimPrefix(r.URL.Path, "/"),
+			Token:   r.Header.Get("Authorization"),
+			Message: msg,
+		})
+		mu.Unlock()
+
+		resp := model.SlackAPIResponse{OK: errorCode == "", Error: errorCode, Channel: "C123", TS: "1700000000.000100"}
+		gt.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []slackAPICall {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]slackAPICall(nil), calls...)
+	}
+}
+
+func TestSlackActionBotMode(t *testing.T) {
+	server, calls := newFakeSlackAPI(t, "")
+	t.Setenv("OCTAP_TEST_SLACK_TOKEN", "xoxb-test")
+
+	action := model.Action{
+		Type: "slack",
+		Data: map[string]interface{}{
+			"token":   "${OCTAP_TEST_SLACK_TOKEN}",
+			"channel": "#ci",
+			"message": "{{.EventType}} {{.Workflow}}",
+		},
+	}
+	slackAction := usecase.NewSlackActionWithAPIURL(server.URL + "/")
+	ctx := context.Background()
+
+	gt.NoError(t, slackAction.Execute(ctx, action, model.WorkflowEvent{
+		Type: model.HookCheckSuccess, Repository: "owner/repo", Workflow: "build", RunID: 1,
+		URL: "https://github.com/owner/repo/actions/runs/1",
+	}))
+	gt.NoError(t, slackAction.Execute(ctx, action, model.WorkflowEvent{
+		Type: model.HookCheckFailure, Repository: "owner/repo", Workflow: "test", RunID: 2,
+	}))
+	gt.NoError(t, slackAction.Execute(ctx, action, model.WorkflowEvent{Type: model.HookCompleteFailure}))
+
+	got := calls()
+	gt.A(t, got).Length(5).Required()
+
+	// The first event posts the parent message
+	gt.Equal(t, got[0].Method, "chat.postMessage")
+	gt.Equal(t, got[0].Token, "Bearer xoxb-test")
+	gt.Equal(t, got[0].Message.Channel, "#ci")
+	gt.Equal(t, got[0].Message.ThreadTS, "")
+	gt.Equal(t, got[0].Message.Text, "⏳ *owner/repo*: workflows running\n✅ <https://github.com/owner/repo/actions/runs/1|build>")
+
+	// A failure updates the parent in place and is threaded beneath it
+	gt.Equal(t, got[1].Method, "chat.update")
+	gt.Equal(t, got[1].Message.Channel, "C123")
+	gt.Equal(t, got[1].Message.TS, "1700000000.000100")
+	gt.True(t, strings.HasSuffix(got[1].Message.Text, "\n❌ test"))
+	gt.Equal(t, got[2].Method, "chat.postMessage")
+	gt.Equal(t, got[2].Message.ThreadTS, "1700000000.000100")
+	gt.Equal(t, got[2].Message.Text, "check_failure test")
+
+	// The final result updates the heading and is threaded too
+	gt.Equal(t, got[3].Method, "chat.update")
+	gt.True(t, strings.HasPrefix(got[3].Message.Text, "❌ *owner/repo*: workflows failed"))
+	gt.Equal(t, got[4].Message.ThreadTS, "1700000000.000100")
+}
+
+func TestSlackActionBotModeAPIError(t *testing.T) {
+	server, calls := newFakeSlackAPI(t, "channel_not_found")
+
+	action := model.Action{
+		Type: "slack",
+		Data: map[string]interface{}{
+			"token":   "xoxb-test",
+			"channel": "#missing",
+			"message": "{{.Workflow}}",
+		},
+	}
+
+	err := usecase.NewSlackActionWithAPIURL(server.URL+"/").Execute(context.Background(), action, model.WorkflowEvent{
+		Type: model.HookCheckFailure, Workflow: "test", RunID: 1,
+	})
+	gt.Error(t, err)
+	// API errors are not retried
+	gt.A(t, calls()).Length(1)
+}
+
+func TestSlackActionBotModeDigest(t *testing.T) {
+	server, calls := newFakeSlackAPI(t, "")
+
+	executor := usecase.NewPolicyHookExecutorWithSlackAPI(&model.Config{Hooks: model.HooksConfig{
+		Policy: model.NotificationPolicy{DigestWindow: time.Hour},
+		CheckFailure: []model.Action{{
+			Type: "slack",
+			Data: map[string]interface{}{
+				"token":   "xoxb-test",
+				"channel": "#ci",
+				"message": "{{.Workflow}} failed",
+			},
+		}},
+	}}, t.TempDir(), server.URL+"/")
+
+	ctx := context.Background()
+	for i, name := range []string{"build", "test", "e2e"} {
+		runID := int64(i + 1)
+		gt.NoError(t, executor.Execute(ctx, model.WorkflowEvent{
+			Type: model.HookCheckFailure, Repository: "owner/repo", Workflow: name, RunID: runID,
+			Runs: []*model.WorkflowRun{{ID: runID, Name: name, URL: fmt.Sprintf("https://github.com/owner/repo/actions/runs/%d", runID)}},
+		})).Required()
+	}
+	executor.WaitForCompletion()
+
+	got := calls()
+	gt.A(t, got).Length(2).Required()
+	// Every run of the digest is listed in the parent message
+	gt.Equal(t, got[0].Method, "chat.postMessage")
+	gt.Equal(t, got[0].Message.Text, "⏳ *owner/repo*: workflows running"+
+		"\n❌ <https://github.com/owner/repo/actions/runs/1|build>"+
+		"\n❌ <https://github.com/owner/repo/actions/runs/2|test>"+
+		"\n❌ <https://github.com/owner/repo/actions/runs/3|e2e>")
+	gt.Equal(t, got[1].Message.Text, "build, test, e2e failed")
+}
+
+func TestSlackActionBlocks(t *testing.T) {
+	var received map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gt.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	action := model.Action{
+		Type: "slack",
+		Data: map[string]interface{}{
+			"webhook_url": server.URL,
+			"message":     "{{.Workflow}} failed",
+			"color":       "danger",
+			// Block Kit Builder documents are accepted as well as plain block lists
+			"blocks": `{"blocks": [{{runsSection .Runs}}]}`,
+		},
+	}
+	event := model.WorkflowEvent{
+		Type:     model.HookCheckFailure,
+		Workflow: "test",
+		Runs: []*model.WorkflowRun{
+			{Name: "build", Status: model.WorkflowStatusCompleted, Conclusion: model.WorkflowConclusionSuccess, URL: "https://example.com/1"},
+			{Name: "a<b", Status: model.WorkflowStatusCompleted, Conclusion: model.WorkflowConclusionFailure},
+			{Name: "lint", Status: model.WorkflowStatusInProgress},
+		},
+	}
+
+	gt.NoError(t, usecase.NewSlackAction().Execute(context.Background(), action, event)).Required()
+
+	// The message is kept as the notification text and the color attachment is dropped
+	gt.Equal(t, received["text"], any("test failed"))
+	gt.Equal(t, received["attachments"], nil)
+	gt.Equal(t, received["blocks"], any([]any{
+		map[string]any{
+			"type": "section",
+			"text": map[string]any{
+				"type": "mrkdwn",
+				"text": "✅ <https://example.com/1|build>\n❌ a&lt;b\n🔄 lint",
+			},
+		},
+	}))
+}
+
+func TestSlackActionInvalidBlocks(t *testing.T) {
+	action := model.Action{
+		Type: "slack",
+		Data: map[string]interface{}{
+			"webhook_url": "https://hooks.slack.com/services/test",
+			"message":     "failed",
+			"blocks":      `[{"text": "no type"}]`,
+		},
+	}
+
+	err := usecase.NewSlackAction().Execute(context.Background(), action, model.WorkflowEvent{Type: model.HookCheckFailure})
+	gt.Error(t, err)
+}
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/m-mizutani/goerr/v2"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+)
+
+// runStatusEmoji returns the icon of a run's conclusion once it completed and of
+// its status before, the same icons as the terminal display
+func runStatusEmoji(run *model.WorkflowRun) string {
+	if run == nil {
+		return unknownEmoji
+	}
+	if run.Status == model.WorkflowStatusCompleted {
+		return emojiFor(run.Conclusion)
+	}
+	return emojiFor(run.Status)
+}
+
+// runsSection renders runs as a Block Kit section block, one linked run per line
+func runsSection(runs []*model.WorkflowRun) (string, error) {
+	lines := make([]string, 0, len(runs))
+	for _, run := range runs {
+		name := slackEscape(run.Name)
+		if run.URL != "" {
+			name = fmt.Sprintf("<%s|%s>", run.URL, name)
+		}
+		lines = append(lines, runStatusEmoji(run)+" "+name)
+	}
+	if len(lines) == 0 {
+		lines = append(lines, "No workflow runs")
+	}
+
+	section := map[string]any{
+		"type": "section",
+		"text": map[string]any{
+			"type": "mrkdwn",
+			"text": strings.Join(lines, "\n"),
+		},
+	}
+	data, err := json.Marshal(section)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// renderSlackBlocks renders a Block Kit template and checks that the result is
+// a list of blocks. The {"blocks": [...]} document exported by Block Kit Builder
+// is accepted as well.
+func renderSlackBlocks(text string, event model.WorkflowEvent) (json.RawMessage, error) {
+	rendered, err := renderTemplate("blocks", text, event)
+	if err != nil {
+		return nil, err
+	}
+
+	var document struct {
+		Blocks []map[string]any `json:"blocks"`
+	}
+	var blocks []map[string]any
+	if err := json.Unmarshal([]byte(rendered), &blocks); err != nil {
+		if docErr := json.Unmarshal([]byte(rendered), &document); docErr != nil || document.Blocks == nil {
+			return nil, goerr.Wrap(err, "slack blocks must render to a JSON array of blocks", goerr.V("blocks", rendered))
+		}
+		blocks = document.Blocks
+	}
+
+	for i, block := range blocks {
+		if blockType, _ := block["type"].(string); blockType == "" {
+			return nil, goerr.New("slack block has no type", goerr.V("index", i))
+		}
+	}
+
+	data, err := json.Marshal(blocks)
+	if err != nil {
+		return nil, goerr.Wrap(err, "failed to marshal slack blocks")
+	}
+	return data, nil
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"os/exec"
 	"runtime"
@@ -37,13 +38,22 @@ func (s *soundAction) Execute(ctx context.Context, action model.Action, event mo
 		return goerr.Wrap(err, "failed to parse sound action")
 	}
 
-	expandedPath := expandPath(soundAction.Path)
+	path, err := renderField("path", soundAction.Path, event)
+	if err != nil {
+		return goerr.Wrap(err, "failed to build sound path")
+	}
+	expandedPath := expandPath(path)
 	logger.Debug("Playing sound",
 		slog.String("original_path", soundAction.Path),
 		slog.String("expanded_path", expandedPath),
 		slog.String("os", runtime.GOOS),
 	)
 
+	if out := dryRunOutput(ctx); out != nil {
+		_, _ = fmt.Fprintf(out, "play %s\n", expandedPath)
+		return nil
+	}
+
 	// Play sound based on OS
 	var playErr error
 	switch runtime.GOOS {
@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/m-mizutani/ctxlog"
+	"github.com/m-mizutani/goerr/v2"
+	"github.com/m-mizutani/octap/pkg/domain/interfaces"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+)
+
+// stateRetention is how long state files are kept after their last update
+const stateRetention = 7 * 24 * time.Hour
+
+type stateStore struct {
+	dir    string
+	pruned bool
+	// err is why the store is turned off, such as an unknown home directory
+	err    error
+	warned bool
+}
+
+// NewStateStore creates a StateStore keeping one file per repository and commit
+// under dir/state. An empty dir selects the default ~/.config/octap directory.
+func NewStateStore(dir string) interfaces.StateStore {
+	stateDir, err := dataPath(dir, "state")
+	return &stateStore{dir: stateDir, err: err}
+}
+
+// disabled reports whether the store is turned off, logging why once
+func (s *stateStore) disabled(ctx context.Context) bool {
+	if s.err == nil {
+		return false
+	}
+	if !s.warned {
+		s.warned = true
+		ctxlog.From(ctx).Warn("monitor state is not saved, so a restart fires hooks again",
+			slog.String("error", s.err.Error()),
+		)
+	}
+	return true
+}
+
+func (s *stateStore) path(repository, commitSHA string) string {
+	// Repository is owner/name, which maps onto a nested directory
+	return filepath.Join(s.dir, filepath.FromSlash(repository), commitSHA+".json")
+}
+
+// Load returns the saved state for the commit, or nil if there is none
+func (s *stateStore) Load(ctx context.Context, repository, commitSHA string) (*model.MonitorState, error) {
+	if s.disabled(ctx) {
+		return nil, nil
+	}
+	path := s.path(repository, commitSHA)
+	data, err := os.ReadFile(path) // #nosec G304 - path is constructed from a fixed directory path
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, goerr.Wrap(err, "failed to read monitor state", goerr.V("path", path))
+	}
+
+	var state model.MonitorState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, goerr.Wrap(err, "failed to parse monitor state", goerr.V("path", path))
+	}
+	return &state, nil
+}
+
+// Save writes the state atomically so that a kill mid-write leaves the previous state intact
+func (s *stateStore) Save(ctx context.Context, state *model.MonitorState) error {
+	if s.disabled(ctx) {
+		return nil
+	}
+	if strings.Contains(state.CommitSHA, "/") || strings.Contains(state.CommitSHA, "..") {
+		return goerr.New("invalid commit SHA for state file", goerr.V("sha", state.CommitSHA))
+	}
+
+	s.prune(ctx)
+
+	path := s.path(state.Repository, state.CommitSHA)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return goerr.Wrap(err, "failed to create state directory")
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return goerr.Wrap(err, "failed to marshal monitor state")
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".state-*")
+	if err != nil {
+		return goerr.Wrap(err, "failed to create temporary state file")
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return goerr.Wrap(err, "failed to write monitor state", goerr.V("path", tmp.Name()))
+	}
+	if err := tmp.Close(); err != nil {
+		return goerr.Wrap(err, "failed to write monitor state", goerr.V("path", tmp.Name()))
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return goerr.Wrap(err, "failed to replace monitor state", goerr.V("path", path))
+	}
+	return nil
+}
+
+// prune removes state files not updated within stateRetention, once per store
+func (s *stateStore) prune(ctx context.Context) {
+	if s.pruned {
+		return
+	}
+	s.pruned = true
+
+	logger := ctxlog.From(ctx)
+	cutoff := time.Now().Add(-stateRetention)
+	_ = filepath.WalkDir(s.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil || info.ModTime().After(cutoff) {
+			return nil
+		}
+		if err := os.Remove(path); err != nil {
+			logger.Debug("failed to remove old monitor state",
+				slog.String("path", path),
+				slog.String("error", err.Error()),
+			)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,45 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/m-mizutani/gt"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+	"github.com/m-mizutani/octap/pkg/usecase"
+)
+
+func TestStateStore(t *testing.T) {
+	ctx := context.Background()
+	store := usecase.NewStateStore(t.TempDir())
+
+	state, err := store.Load(ctx, "owner/repo", "abc1234")
+	gt.NoError(t, err)
+	gt.Nil(t, state)
+
+	gt.NoError(t, store.Save(ctx, &model.MonitorState{
+		Repository:     "owner/repo",
+		CommitSHA:      "abc1234",
+		Runs:           []*model.WorkflowRun{{ID: 1, Name: "test"}},
+		NotifiedRunIDs: []int64{1},
+	}))
+	gt.NoError(t, store.Save(ctx, &model.MonitorState{
+		Repository:    "owner/repo",
+		CommitSHA:     "abc1234",
+		Runs:          []*model.WorkflowRun{{ID: 1, Name: "test"}},
+		CompleteFired: true,
+	}))
+
+	state, err = store.Load(ctx, "owner/repo", "abc1234")
+	gt.NoError(t, err)
+	gt.NotNil(t, state)
+	gt.True(t, state.CompleteFired)
+	gt.A(t, state.Runs).Length(1)
+
+	// Other commits have their own state
+	other, err := store.Load(ctx, "owner/repo", "def5678")
+	gt.NoError(t, err)
+	gt.Nil(t, other)
+
+	gt.Error(t, store.Save(ctx, &model.MonitorState{Repository: "owner/repo", CommitSHA: "../escape"}))
+}
@@ -0,0 +1,240 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/m-mizutani/ctxlog"
+	"github.com/m-mizutani/goerr/v2"
+	"github.com/m-mizutani/octap/pkg/domain"
+	"github.com/m-mizutani/octap/pkg/domain/interfaces"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+)
+
+// DefaultFlakyThreshold is the flip-flop rate at which a workflow is considered flaky
+const DefaultFlakyThreshold = 0.1
+
+type StatsUseCase struct {
+	github  interfaces.GitHubService
+	history interfaces.HistoryStore
+	repo    model.Repository
+}
+
+type StatsUseCaseOptions struct {
+	// GitHub is required for StatsSourceAPI
+	GitHub interfaces.GitHubService
+	// History is required for StatsSourceHistory
+	History interfaces.HistoryStore
+	Repo    model.Repository
+}
+
+// StatsQuery selects the runs statistics are computed from
+type StatsQuery struct {
+	Source model.StatsSource
+	Branch string
+	// Limit is the number of recent runs (API) or sessions (history) to examine
+	Limit          int
+	FlakyThreshold float64
+}
+
+func NewStatsUseCase(opts StatsUseCaseOptions) *StatsUseCase {
+	return &StatsUseCase{
+		github:  opts.GitHub,
+		history: opts.History,
+		repo:    opts.Repo,
+	}
+}
+
+// Collect fetches recent runs from the query's source and computes per-workflow statistics
+func (u *StatsUseCase) Collect(ctx context.Context, query StatsQuery) ([]*model.WorkflowStats, error) {
+	var runs []*model.WorkflowRun
+	var err error
+
+	switch query.Source {
+	case model.StatsSourceAPI, "":
+		runs, err = u.runsFromAPI(ctx, query)
+	case model.StatsSourceHistory:
+		runs, err = u.runsFromHistory(ctx, query)
+	default:
+		return nil, goerr.Wrap(domain.ErrConfiguration, "invalid stats source", goerr.V("source", query.Source))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	threshold := query.FlakyThreshold
+	if threshold <= 0 {
+		threshold = DefaultFlakyThreshold
+	}
+	return ComputeWorkflowStats(runs, threshold), nil
+}
+
+func (u *StatsUseCase) runsFromAPI(ctx context.Context, query StatsQuery) ([]*model.WorkflowRun, error) {
+	logger := ctxlog.From(ctx)
+
+	runs, err := u.github.ListWorkflowRuns(ctx, u.repo, model.RunFilter{
+		Branch: query.Branch,
+		Limit:  query.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The list only returns the latest attempt of each run. Earlier attempts are
+	// where flip-flops show up, so fetch them for re-run workflows.
+	var attempts []*model.WorkflowRun
+	for _, run := range runs {
+		for attempt := 1; attempt < run.RunAttempt; attempt++ {
+			previous, err := u.github.GetWorkflowRunAttempt(ctx, u.repo, run.ID, attempt)
+			if err != nil {
+				logger.Debug("failed to fetch previous run attempt",
+					slog.Int64("run_id", run.ID),
+					slog.Int("attempt", attempt),
+					slog.String("error", err.Error()),
+				)
+				continue
+			}
+			attempts = append(attempts, previous)
+		}
+	}
+
+	return append(runs, attempts...), nil
+}
+
+func (u *StatsUseCase) runsFromHistory(ctx context.Context, query StatsQuery) ([]*model.WorkflowRun, error) {
+	if u.history == nil {
+		return nil, goerr.Wrap(domain.ErrConfiguration, "history store is not available")
+	}
+
+	sessions, err := u.history.List(ctx, model.HistoryFilter{
+		Repository: u.repo.FullName(),
+		Branch:     query.Branch,
+		Limit:      query.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var runs []*model.WorkflowRun
+	for _, session := range sessions {
+		for _, run := range session.Runs {
+			if run.HeadSHA == "" {
+				// Sessions recorded before runs carried their SHA
+				copied := *run
+				copied.HeadSHA = session.CommitSHA
+				run = &copied
+			}
+			runs = append(runs, run)
+		}
+	}
+	return runs, nil
+}
+
+// ComputeWorkflowStats aggregates completed runs per workflow name. Runs of the
+// same workflow for the same commit, such as re-run attempts, are compared to
+// find flip-flops: commits where the workflow both failed and succeeded without
+// a code change. Workflows whose flip-flop rate reaches threshold are flagged flaky.
+func ComputeWorkflowStats(runs []*model.WorkflowRun, threshold float64) []*model.WorkflowStats {
+	type outcome struct {
+		success, failure bool
+	}
+	type aggregate struct {
+		stats     *model.WorkflowStats
+		durations []time.Duration
+		queues    []time.Duration
+		commits   map[string]*outcome
+	}
+
+	byName := make(map[string]*aggregate)
+	for _, run := range runs {
+		if run.Status != model.WorkflowStatusCompleted {
+			continue
+		}
+
+		agg, ok := byName[run.Name]
+		if !ok {
+			agg = &aggregate{
+				stats:   &model.WorkflowStats{Workflow: run.Name},
+				commits: make(map[string]*outcome),
+			}
+			byName[run.Name] = agg
+		}
+		agg.stats.TotalRuns++
+
+		switch run.Conclusion {
+		case model.WorkflowConclusionSuccess:
+			agg.stats.SuccessCount++
+		case model.WorkflowConclusionFailure:
+			agg.stats.FailureCount++
+		default:
+			// Cancelled and skipped runs say nothing about the workflow's health
+			continue
+		}
+
+		if d := run.RunDuration(); d > 0 {
+			agg.durations = append(agg.durations, d)
+		}
+		if q := run.QueueTime(); q > 0 {
+			agg.queues = append(agg.queues, q)
+		}
+
+		if run.HeadSHA != "" {
+			o, ok := agg.commits[run.HeadSHA]
+			if !ok {
+				o = &outcome{}
+				agg.commits[run.HeadSHA] = o
+			}
+			if run.Conclusion == model.WorkflowConclusionSuccess {
+				o.success = true
+			} else {
+				o.failure = true
+			}
+		}
+	}
+
+	result := make([]*model.WorkflowStats, 0, len(byName))
+	for _, agg := range byName {
+		stats := agg.stats
+		if decided := stats.SuccessCount + stats.FailureCount; decided > 0 {
+			stats.SuccessRate = float64(stats.SuccessCount) / float64(decided)
+		}
+		stats.P50Duration = percentileDuration(agg.durations, 0.5)
+		stats.P95Duration = percentileDuration(agg.durations, 0.95)
+		stats.P50QueueTime = percentileDuration(agg.queues, 0.5)
+
+		stats.Commits = len(agg.commits)
+		for _, o := range agg.commits {
+			if o.success && o.failure {
+				stats.FlipFlops++
+			}
+		}
+		if stats.Commits > 0 {
+			stats.FlipFlopRate = float64(stats.FlipFlops) / float64(stats.Commits)
+		}
+		stats.Flaky = stats.FlipFlops > 0 && stats.FlipFlopRate >= threshold
+
+		result = append(result, stats)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Workflow < result[j].Workflow })
+	return result
+}
+
+// percentileDuration returns the nearest-rank percentile p (0-1] of durations
+func percentileDuration(durations []time.Duration, p float64) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rank := int(math.Ceil(p * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
@@ -0,0 +1,134 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/m-mizutani/gt"
+	"github.com/m-mizutani/octap/pkg/domain/interfaces"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+	"github.com/m-mizutani/octap/pkg/usecase"
+)
+
+// statsGitHubService returns canned runs and previous attempts
+type statsGitHubService struct {
+	interfaces.GitHubService
+	runs     []*model.WorkflowRun
+	attempts map[int64][]*model.WorkflowRun
+}
+
+func (s *statsGitHubService) ListWorkflowRuns(ctx context.Context, repo model.Repository, filter model.RunFilter) ([]*model.WorkflowRun, error) {
+	return s.runs, nil
+}
+
+func (s *statsGitHubService) GetWorkflowRunAttempt(ctx context.Context, repo model.Repository, runID int64, attempt int) (*model.WorkflowRun, error) {
+	return s.attempts[runID][attempt-1], nil
+}
+
+func TestComputeWorkflowStats(t *testing.T) {
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	run := func(name, sha string, conclusion model.WorkflowConclusion, queue, duration time.Duration) *model.WorkflowRun {
+		return &model.WorkflowRun{
+			Name:       name,
+			HeadSHA:    sha,
+			Status:     model.WorkflowStatusCompleted,
+			Conclusion: conclusion,
+			CreatedAt:  base,
+			StartedAt:  base.Add(queue),
+			UpdatedAt:  base.Add(queue + duration),
+		}
+	}
+
+	stats := usecase.ComputeWorkflowStats([]*model.WorkflowRun{
+		run("test", "sha1", model.WorkflowConclusionFailure, 10*time.Second, 2*time.Minute),
+		run("test", "sha1", model.WorkflowConclusionSuccess, 20*time.Second, 3*time.Minute),
+		run("test", "sha2", model.WorkflowConclusionSuccess, 30*time.Second, 4*time.Minute),
+		run("build", "sha1", model.WorkflowConclusionSuccess, 0, time.Minute),
+		run("build", "sha2", model.WorkflowConclusionFailure, 0, time.Minute),
+		run("build", "sha3", model.WorkflowConclusionCancelled, 0, time.Minute),
+		{Name: "build", HeadSHA: "sha4", Status: model.WorkflowStatusInProgress},
+	}, 0.1)
+
+	gt.A(t, stats).Length(2)
+
+	build := stats[0]
+	gt.Equal(t, build.Workflow, "build")
+	gt.Equal(t, build.TotalRuns, 3)
+	gt.Equal(t, build.SuccessRate, 0.5)
+	gt.Equal(t, build.FlipFlops, 0)
+	gt.False(t, build.Flaky)
+
+	test := stats[1]
+	gt.Equal(t, test.Workflow, "test")
+	gt.Equal(t, test.TotalRuns, 3)
+	gt.Equal(t, test.SuccessCount, 2)
+	gt.Equal(t, test.FailureCount, 1)
+	gt.Equal(t, test.P50Duration, 3*time.Minute)
+	gt.Equal(t, test.P95Duration, 4*time.Minute)
+	gt.Equal(t, test.P50QueueTime, 20*time.Second)
+	gt.Equal(t, test.Commits, 2)
+	gt.Equal(t, test.FlipFlops, 1)
+	gt.Equal(t, test.FlipFlopRate, 0.5)
+	gt.True(t, test.Flaky)
+
+	t.Run("threshold above the flip-flop rate", func(t *testing.T) {
+		stats := usecase.ComputeWorkflowStats([]*model.WorkflowRun{
+			run("test", "sha1", model.WorkflowConclusionFailure, 0, time.Minute),
+			run("test", "sha1", model.WorkflowConclusionSuccess, 0, time.Minute),
+			run("test", "sha2", model.WorkflowConclusionSuccess, 0, time.Minute),
+		}, 0.8)
+		gt.False(t, stats[0].Flaky)
+	})
+}
+
+func TestStatsUseCaseFetchesPreviousAttempts(t *testing.T) {
+	github := &statsGitHubService{
+		runs: []*model.WorkflowRun{
+			{ID: 1, Name: "test", HeadSHA: "sha1", RunAttempt: 2, Status: model.WorkflowStatusCompleted, Conclusion: model.WorkflowConclusionSuccess},
+			{ID: 2, Name: "test", HeadSHA: "sha2", RunAttempt: 1, Status: model.WorkflowStatusCompleted, Conclusion: model.WorkflowConclusionSuccess},
+		},
+		attempts: map[int64][]*model.WorkflowRun{
+			1: {{ID: 1, Name: "test", HeadSHA: "sha1", RunAttempt: 1, Status: model.WorkflowStatusCompleted, Conclusion: model.WorkflowConclusionFailure}},
+		},
+	}
+
+	uc := usecase.NewStatsUseCase(usecase.StatsUseCaseOptions{
+		GitHub: github,
+		Repo:   model.Repository{Owner: "owner", Name: "repo"},
+	})
+	stats, err := uc.Collect(context.Background(), usecase.StatsQuery{Source: model.StatsSourceAPI})
+	gt.NoError(t, err)
+	gt.A(t, stats).Length(1)
+	gt.Equal(t, stats[0].TotalRuns, 3)
+	gt.Equal(t, stats[0].FlipFlops, 1)
+	gt.True(t, stats[0].Flaky)
+}
+
+func TestStatsUseCaseFromHistory(t *testing.T) {
+	ctx := context.Background()
+	store := usecase.NewHistoryStore(t.TempDir())
+	save := func(repo, sha string, conclusion model.WorkflowConclusion) {
+		gt.NoError(t, store.Save(ctx, &model.Session{
+			Repository: repo,
+			CommitSHA:  sha,
+			Runs: []*model.WorkflowRun{
+				{Name: "test", Status: model.WorkflowStatusCompleted, Conclusion: conclusion},
+			},
+		}))
+	}
+	save("owner/repo", "sha1", model.WorkflowConclusionFailure)
+	save("owner/repo", "sha1", model.WorkflowConclusionSuccess)
+	save("owner/other", "sha2", model.WorkflowConclusionFailure)
+
+	uc := usecase.NewStatsUseCase(usecase.StatsUseCaseOptions{
+		History: store,
+		Repo:    model.Repository{Owner: "owner", Name: "repo"},
+	})
+	stats, err := uc.Collect(ctx, usecase.StatsQuery{Source: model.StatsSourceHistory})
+	gt.NoError(t, err)
+	gt.A(t, stats).Length(1)
+	gt.Equal(t, stats[0].TotalRuns, 2)
+	gt.Equal(t, stats[0].FlipFlops, 1)
+	gt.True(t, stats[0].Flaky)
+}
@@ -3,20 +3,25 @@ package usecase
 import (
 	"context"
 	"encoding/json"
+	"log/slog"
 	"os"
 	"path/filepath"
 
+	"github.com/m-mizutani/ctxlog"
 	"github.com/m-mizutani/octap/pkg/domain"
 )
 
 type TokenStorage struct {
 	configDir string
+	// err is why the token is not stored, such as an unknown home directory
+	err error
 }
 
 func NewTokenStorage() *TokenStorage {
-	homeDir, _ := os.UserHomeDir()
+	configDir, err := defaultConfigDir()
 	return &TokenStorage{
-		configDir: filepath.Join(homeDir, ".config", "octap"),
+		configDir: configDir,
+		err:       err,
 	}
 }
 
@@ -29,6 +34,13 @@ type tokenData struct {
 }
 
 func (s *TokenStorage) SaveToken(ctx context.Context, token string) error {
+	if s.err != nil {
+		// The token is only used by this process rather than being written to a relative path
+		ctxlog.From(ctx).Warn("authentication token is not saved",
+			slog.String("error", s.err.Error()),
+		)
+		return nil
+	}
 	if err := os.MkdirAll(s.configDir, 0700); err != nil {
 		return domain.ErrConfiguration.Wrap(err)
 	}
@@ -48,6 +60,9 @@ func (s *TokenStorage) SaveToken(ctx context.Context, token string) error {
 }
 
 func (s *TokenStorage) GetToken(ctx context.Context) (string, error) {
+	if s.err != nil {
+		return "", nil
+	}
 	tokenPath := s.getTokenPath()
 	data, err := os.ReadFile(tokenPath) // #nosec G304 - tokenPath is constructed from a fixed directory path
 	if err != nil {
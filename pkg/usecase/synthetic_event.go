@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/m-mizutani/goerr/v2"
+	"github.com/m-mizutani/octap/pkg/domain/interfaces"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+)
+
+// SyntheticEventOptions describes the event fired by `octap hook test`. Empty
+// fields keep the value of the run taken from GitHub, or a sample value.
+type SyntheticEventOptions struct {
+	Type model.HookEvent
+	Repo model.Repository
+	// RunID takes the run and the other runs of its commit from GitHub
+	RunID  int64
+	GitHub interfaces.GitHubService
+
+	Workflow string
+	Branch   string
+	SHA      string
+	Message  string
+	Author   string
+	Actor    string
+	// Conclusion overrides the conclusion that matches the event type
+	Conclusion model.WorkflowConclusion
+	Duration   time.Duration
+}
+
+// NewSyntheticEvent builds an event as the monitor would fire it, for testing hooks
+// without waiting for CI. The run's status and conclusion are set to match the event type.
+func NewSyntheticEvent(ctx context.Context, opts SyntheticEventOptions) (model.WorkflowEvent, error) {
+	if !slices.Contains(model.HookEvents, opts.Type) {
+		return model.WorkflowEvent{}, goerr.New("unknown hook event", goerr.V("event", opts.Type))
+	}
+
+	now := time.Now()
+	run, runs, err := syntheticRuns(ctx, opts, now)
+	if err != nil {
+		return model.WorkflowEvent{}, err
+	}
+
+	if opts.Workflow != "" {
+		run.Name = opts.Workflow
+	}
+	if opts.Branch != "" {
+		run.Branch = opts.Branch
+	}
+	if opts.SHA != "" {
+		run.HeadSHA = opts.SHA
+	}
+	if opts.Message != "" {
+		run.CommitMessage = opts.Message
+	}
+	if opts.Author != "" {
+		run.CommitAuthor = opts.Author
+	}
+	if opts.Actor != "" {
+		run.Actor = opts.Actor
+	}
+	if opts.Duration > 0 {
+		run.CreatedAt = now.Add(-opts.Duration)
+		run.StartedAt = run.CreatedAt
+		run.UpdatedAt = now
+	}
+
+	run.Status, run.Conclusion = syntheticRunState(opts.Type)
+	if opts.Conclusion != "" {
+		run.Status = model.WorkflowStatusCompleted
+		run.Conclusion = opts.Conclusion
+	}
+
+	if opts.Type.IsSummary() {
+		// The other runs of a real commit keep their state
+		return summaryEvent(opts.Type, summarizeRuns(runs, run.Elapsed(now))), nil
+	}
+
+	completed := 0
+	for _, r := range runs {
+		if r.Status == model.WorkflowStatusCompleted {
+			completed++
+		}
+	}
+	progress := model.Progress{Completed: completed, Total: len(runs), Runs: runs}
+	return runHookEvent(opts.Type, run, progress, now), nil
+}
+
+// syntheticRuns returns the run the event is about and all runs of its commit
+func syntheticRuns(ctx context.Context, opts SyntheticEventOptions, now time.Time) (*model.WorkflowRun, []*model.WorkflowRun, error) {
+	if opts.RunID == 0 {
+		run := &model.WorkflowRun{
+			ID:            1,
+			WorkflowID:    1,
+			Name:          "CI",
+			Repository:    opts.Repo.FullName(),
+			Branch:        "main",
+			HeadSHA:       "0123456789abcdef0123456789abcdef01234567",
+			CommitMessage: "Test commit from octap hook test",
+			CommitAuthor:  "octocat",
+			Actor:         "octocat",
+			RunAttempt:    1,
+			URL:           fmt.Sprintf("https://github.com/%s/actions/runs/1", opts.Repo.FullName()),
+			CreatedAt:     now.Add(-2 * time.Minute),
+			StartedAt:     now.Add(-2 * time.Minute),
+			UpdatedAt:     now,
+		}
+		return run, []*model.WorkflowRun{run}, nil
+	}
+
+	if opts.GitHub == nil {
+		return nil, nil, goerr.New("GitHub service is required to take a real run", goerr.V("run_id", opts.RunID))
+	}
+	run, err := opts.GitHub.GetWorkflowRun(ctx, opts.Repo, opts.RunID)
+	if err != nil {
+		return nil, nil, goerr.Wrap(err, "failed to get workflow run", goerr.V("run_id", opts.RunID))
+	}
+	commitRuns, err := opts.GitHub.GetWorkflowRuns(ctx, opts.Repo, run.HeadSHA)
+	if err != nil {
+		return nil, nil, goerr.Wrap(err, "failed to get workflow runs of the commit", goerr.V("sha", run.HeadSHA))
+	}
+
+	// The event's run replaces its copy in the list so that overrides apply to both
+	runs := []*model.WorkflowRun{run}
+	for _, r := range commitRuns {
+		if r.ID != run.ID {
+			runs = append(runs, r)
+		}
+	}
+	return run, runs, nil
+}
+
+// syntheticRunState returns the status and conclusion of the run that fires the event
+func syntheticRunState(event model.HookEvent) (model.WorkflowStatus, model.WorkflowConclusion) {
+	switch event {
+	case model.HookRunStarted, model.HookMonitorAborted:
+		return model.WorkflowStatusInProgress, ""
+	case model.HookRunQueuedTooLong:
+		return model.WorkflowStatusQueued, ""
+	case model.HookCheckSuccess, model.HookCompleteSuccess, model.HookRecovered:
+		return model.WorkflowStatusCompleted, model.WorkflowConclusionSuccess
+	case model.HookCheckCancelled:
+		return model.WorkflowStatusCompleted, model.WorkflowConclusionCancelled
+	case model.HookCheckTimedOut:
+		return model.WorkflowStatusCompleted, model.WorkflowConclusionTimedOut
+	case model.HookCheckSkipped:
+		return model.WorkflowStatusCompleted, model.WorkflowConclusionSkipped
+	default:
+		return model.WorkflowStatusCompleted, model.WorkflowConclusionFailure
+	}
+}
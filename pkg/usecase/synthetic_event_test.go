@@ -0,0 +1,98 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/m-mizutani/gt"
+	"github.com/m-mizutani/octap/pkg/domain/interfaces"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+	"github.com/m-mizutani/octap/pkg/usecase"
+)
+
+// runGitHubService returns a single run and the runs of its commit
+type runGitHubService struct {
+	interfaces.GitHubService
+	run        *model.WorkflowRun
+	commitRuns []*model.WorkflowRun
+}
+
+func (s *runGitHubService) GetWorkflowRun(ctx context.Context, repo model.Repository, runID int64) (*model.WorkflowRun, error) {
+	return s.run, nil
+}
+
+func (s *runGitHubService) GetWorkflowRuns(ctx context.Context, repo model.Repository, commitSHA string) ([]*model.WorkflowRun, error) {
+	return s.commitRuns, nil
+}
+
+func TestNewSyntheticEvent(t *testing.T) {
+	ctx := context.Background()
+	repo := model.Repository{Owner: "owner", Name: "repo"}
+
+	t.Run("sample run with overrides", func(t *testing.T) {
+		event, err := usecase.NewSyntheticEvent(ctx, usecase.SyntheticEventOptions{
+			Type:     model.HookCheckFailure,
+			Repo:     repo,
+			Workflow: "test",
+			Branch:   "feature",
+			Duration: 5 * time.Minute,
+		})
+		gt.NoError(t, err).Required()
+
+		gt.Equal(t, event.Type, model.HookCheckFailure)
+		gt.Equal(t, event.Repository, "owner/repo")
+		gt.Equal(t, event.Workflow, "test")
+		gt.Equal(t, event.Commit.Branch, "feature")
+		gt.Equal(t, event.Duration, 5*time.Minute)
+		gt.Equal(t, event.URL, "https://github.com/owner/repo/actions/runs/1")
+		gt.A(t, event.Runs).Length(1).At(0, func(t testing.TB, run *model.WorkflowRun) {
+			gt.Equal(t, run.Conclusion, model.WorkflowConclusionFailure)
+		})
+		gt.Nil(t, event.Summary)
+	})
+
+	t.Run("state matches the event unless overridden", func(t *testing.T) {
+		event, err := usecase.NewSyntheticEvent(ctx, usecase.SyntheticEventOptions{Type: model.HookRunQueuedTooLong, Repo: repo})
+		gt.NoError(t, err).Required()
+		gt.Equal(t, event.Runs[0].Status, model.WorkflowStatusQueued)
+
+		event, err = usecase.NewSyntheticEvent(ctx, usecase.SyntheticEventOptions{
+			Type:       model.HookCompleteFailure,
+			Repo:       repo,
+			Conclusion: model.WorkflowConclusionTimedOut,
+		})
+		gt.NoError(t, err).Required()
+		gt.Equal(t, event.Runs[0].Conclusion, model.WorkflowConclusionTimedOut)
+		gt.Equal(t, event.Summary.TotalRuns, 1)
+		gt.Equal(t, event.Summary.OtherCount, 1)
+	})
+
+	t.Run("real run and its commit", func(t *testing.T) {
+		github := &runGitHubService{
+			run: &model.WorkflowRun{ID: 10, Name: "build", HeadSHA: "abc1234", Branch: "main", Repository: "owner/repo",
+				Status: model.WorkflowStatusCompleted, Conclusion: model.WorkflowConclusionSuccess},
+			commitRuns: []*model.WorkflowRun{
+				{ID: 10, Name: "build", HeadSHA: "abc1234", Status: model.WorkflowStatusCompleted, Conclusion: model.WorkflowConclusionSuccess},
+				{ID: 11, Name: "lint", HeadSHA: "abc1234", Status: model.WorkflowStatusCompleted, Conclusion: model.WorkflowConclusionFailure},
+			},
+		}
+		event, err := usecase.NewSyntheticEvent(ctx, usecase.SyntheticEventOptions{
+			Type:   model.HookCompleteFailure,
+			Repo:   repo,
+			RunID:  10,
+			GitHub: github,
+		})
+		gt.NoError(t, err).Required()
+
+		gt.Equal(t, event.Commit.SHA, "abc1234")
+		gt.A(t, event.Runs).Length(2)
+		// The event's run failed, as complete_failure says, and lint keeps its state
+		gt.Equal(t, event.Summary.FailureCount, 2)
+	})
+
+	t.Run("unknown event", func(t *testing.T) {
+		_, err := usecase.NewSyntheticEvent(ctx, usecase.SyntheticEventOptions{Type: "check_maybe", Repo: repo})
+		gt.Error(t, err)
+	})
+}
@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/m-mizutani/ctxlog"
+	"github.com/m-mizutani/goerr/v2"
+	"github.com/m-mizutani/octap/pkg/domain/interfaces"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+)
+
+// teamsColors maps named colors to Adaptive Card text colors
+var teamsColors = map[string]string{
+	"good":    "Good",
+	"warning": "Warning",
+	"danger":  "Attention",
+}
+
+type teamsAction struct {
+	httpClient *http.Client
+}
+
+// NewTeamsAction creates a new TeamsAction instance
+func NewTeamsAction() interfaces.ActionExecutor {
+	return &teamsAction{
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// Execute posts an Adaptive Card to a Teams Workflows or Incoming Webhook URL
+func (t *teamsAction) Execute(ctx context.Context, action model.Action, event model.WorkflowEvent) error {
+	logger := ctxlog.From(ctx)
+
+	logger.Debug("teamsAction.Execute called",
+		slog.String("event_type", string(event.Type)),
+	)
+
+	teamsAction, err := action.ToTeamsAction()
+	if err != nil {
+		logger.Error("Failed to parse teams action",
+			slog.String("error", err.Error()),
+		)
+		return goerr.Wrap(err, "failed to parse teams action")
+	}
+
+	webhookURL, err := renderField("webhook_url", teamsAction.WebhookURL, event)
+	if err != nil {
+		return goerr.Wrap(err, "failed to build webhook URL")
+	}
+	if webhookURL == "" {
+		return goerr.New("webhook URL is empty after expansion")
+	}
+
+	payload, err := t.buildPayload(teamsAction, event)
+	if err != nil {
+		return err
+	}
+
+	err = postJSON(ctx, t.httpClient, webhookURL, payload)
+	if err != nil {
+		return goerr.Wrap(err, "failed to send teams notification", goerr.V("url", maskWebhookURL(webhookURL)))
+	}
+
+	logger.Debug("Teams notification sent successfully")
+	return nil
+}
+
+// buildPayload renders the templates into an Adaptive Card message
+func (t *teamsAction) buildPayload(action *model.TeamsAction, event model.WorkflowEvent) (*model.TeamsPayload, error) {
+	message, err := renderTemplate("message", action.Message, event)
+	if err != nil {
+		return nil, goerr.Wrap(err, "failed to build message")
+	}
+
+	title := "octap"
+	if action.Title != "" {
+		if title, err = renderTemplate("title", action.Title, event); err != nil {
+			return nil, goerr.Wrap(err, "failed to build title")
+		}
+	}
+
+	fields, err := renderMessageFields(action.Fields, event)
+	if err != nil {
+		return nil, err
+	}
+
+	card := model.AdaptiveCard{
+		Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+		Type:    "AdaptiveCard",
+		Version: "1.4",
+		Body: []model.AdaptiveCardElement{
+			{
+				Type:   "TextBlock",
+				Text:   title,
+				Weight: "Bolder",
+				Size:   "Medium",
+				Color:  teamsColors[messageColor(action.Color, event.Type)],
+				Wrap:   true,
+			},
+			{
+				Type: "TextBlock",
+				Text: message,
+				Wrap: true,
+			},
+		},
+	}
+
+	if len(fields) > 0 {
+		facts := model.AdaptiveCardElement{Type: "FactSet"}
+		for _, field := range fields {
+			facts.Facts = append(facts.Facts, model.AdaptiveCardFact{Title: field.Name, Value: field.Value})
+		}
+		card.Body = append(card.Body, facts)
+	}
+
+	if event.URL != "" {
+		card.Actions = []model.AdaptiveCardAction{
+			{Type: "Action.OpenUrl", Title: "Open run", URL: event.URL},
+		}
+	}
+
+	return &model.TeamsPayload{
+		Type: "message",
+		Attachments: []model.TeamsAttachment{
+			{
+				ContentType: "application/vnd.microsoft.card.adaptive",
+				Content:     card,
+			},
+		},
+	}, nil
+}
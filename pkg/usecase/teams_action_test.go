@@ -0,0 +1,54 @@
+package usecase_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/m-mizutani/gt"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+	"github.com/m-mizutani/octap/pkg/usecase"
+)
+
+func TestTeamsAction(t *testing.T) {
+	var received model.TeamsPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gt.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	action := model.Action{
+		Type: "teams",
+		Data: map[string]interface{}{
+			"webhook_url": server.URL,
+			"title":       "{{.Workflow}} passed",
+			"message":     "in {{.Repository}}",
+			"fields": []interface{}{
+				map[string]interface{}{"name": "Duration", "value": "{{.Duration}}"},
+			},
+		},
+	}
+	event := model.WorkflowEvent{
+		Type:       model.HookCheckSuccess,
+		Repository: "owner/repo",
+		Workflow:   "build",
+		URL:        "https://github.com/owner/repo/actions/runs/1",
+	}
+
+	gt.NoError(t, usecase.NewTeamsAction().Execute(context.Background(), action, event))
+	gt.Equal(t, received.Type, "message")
+	gt.A(t, received.Attachments).Length(1)
+
+	card := received.Attachments[0].Content
+	gt.Equal(t, card.Type, "AdaptiveCard")
+	gt.A(t, card.Body).Length(3)
+	gt.Equal(t, card.Body[0].Text, "build passed")
+	gt.Equal(t, card.Body[0].Color, "Good")
+	gt.Equal(t, card.Body[1].Text, "in owner/repo")
+	gt.A(t, card.Body[2].Facts).Length(1)
+	gt.A(t, card.Actions).Length(1)
+	gt.Equal(t, card.Actions[0].URL, event.URL)
+}
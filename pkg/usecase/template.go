@@ -0,0 +1,276 @@
+package usecase
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/m-mizutani/goerr/v2"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+)
+
+// templateData is the data available to message templates of hook actions
+type templateData struct {
+	Repository string
+	Workflow   string
+	RunID      int64
+	EventType  string
+	RunURL     string
+	Timestamp  time.Time
+	Duration   time.Duration
+	ETA        time.Time
+	Remaining  time.Duration
+	Runs       []*model.WorkflowRun
+	Commit     model.Commit
+	// Summary is zero for check events
+	Summary model.Summary
+	// Outputs are the outputs of earlier actions of the event, e.g. {{.Outputs.upload.url}}
+	Outputs map[string]map[string]string
+	// Digest are the runs collapsed into a check event by the digest window
+	Digest []*model.WorkflowRun
+}
+
+// templateFuncs are the functions available in every template
+var templateFuncs = template.FuncMap{
+	// json encodes a value as JSON, for building JSON bodies safely
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	},
+	// statusEmoji returns the status icon of a workflow run
+	"statusEmoji": runStatusEmoji,
+	// runsSection renders workflow runs as a Slack Block Kit section
+	"runsSection": runsSection,
+	// shortSHA abbreviates a commit SHA to 7 characters
+	"shortSHA": model.ShortSHA,
+	// duration formats a duration for humans, e.g. 1h 2m or 3m 5s
+	"duration": model.FormatDuration,
+	"upper":    strings.ToUpper,
+	"lower":    strings.ToLower,
+	// join concatenates the elements of a list, e.g. {{join ", " .Runs}} for the run names
+	"join": joinList,
+	// emoji returns the icon of an event type, run, status or conclusion
+	"emoji": emojiFor,
+	// default returns the fallback when the value is empty, e.g. {{.Commit.Author | default "someone"}}
+	"default": defaultValue,
+}
+
+func joinList(sep string, list any) (string, error) {
+	v := reflect.ValueOf(list)
+	if !v.IsValid() {
+		return "", nil
+	}
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return "", goerr.New("join expects a list", goerr.V("type", v.Type().String()))
+	}
+
+	items := make([]string, v.Len())
+	for i := range items {
+		switch item := v.Index(i).Interface().(type) {
+		case *model.WorkflowRun:
+			// Runs are joined by name, e.g. {{join ", " .Runs}}
+			if item != nil {
+				items[i] = item.Name
+			}
+		default:
+			items[i] = fmt.Sprint(item)
+		}
+	}
+	return strings.Join(items, sep), nil
+}
+
+// statusEmojis are the icons of hook events, run statuses and run conclusions,
+// shared by the emoji and statusEmoji template functions and Slack messages
+var statusEmojis = map[string]string{
+	string(model.HookCheckSuccess):            "✅",
+	string(model.HookCompleteSuccess):         "✅",
+	string(model.WorkflowConclusionSuccess):   "✅",
+	string(model.HookCheckFailure):            "❌",
+	string(model.HookCompleteFailure):         "❌",
+	string(model.HookFirstFailure):            "❌",
+	string(model.WorkflowConclusionFailure):   "❌",
+	string(model.HookMonitorAborted):          "⏹️",
+	string(model.HookCheckCancelled):          "⚪",
+	string(model.WorkflowConclusionCancelled): "⚪",
+	string(model.HookCheckSkipped):            "⏭️",
+	string(model.WorkflowConclusionSkipped):   "⏭️",
+	string(model.HookCheckTimedOut):           "⌛",
+	string(model.WorkflowConclusionTimedOut):  "⌛",
+	string(model.HookRunStarted):              "🔄",
+	string(model.WorkflowStatusInProgress):    "🔄",
+	string(model.HookRunQueuedTooLong):        "⏳",
+	string(model.WorkflowStatusQueued):        "⏳",
+	string(model.HookRecovered):               "💚",
+}
+
+// unknownEmoji is the icon of anything not in statusEmojis
+const unknownEmoji = "❓"
+
+func emojiFor(v any) string {
+	var key string
+	switch v := v.(type) {
+	case *model.WorkflowRun:
+		return runStatusEmoji(v)
+	case model.HookEvent:
+		key = string(v)
+	case model.WorkflowStatus:
+		key = string(v)
+	case model.WorkflowConclusion:
+		key = string(v)
+	case string:
+		key = v
+	}
+
+	if emoji, ok := statusEmojis[key]; ok {
+		return emoji
+	}
+	return unknownEmoji
+}
+
+func defaultValue(fallback, value any) any {
+	if value == nil {
+		return fallback
+	}
+	if v := reflect.ValueOf(value); v.IsZero() {
+		return fallback
+	}
+	return value
+}
+
+func newTemplateData(event model.WorkflowEvent, now time.Time) templateData {
+	// Remaining is zero when the ETA is unknown or has passed
+	var remaining time.Duration
+	if !event.ETA.IsZero() && event.ETA.After(now) {
+		remaining = event.ETA.Sub(now).Round(time.Second)
+	}
+
+	data := templateData{
+		Repository: event.Repository,
+		Workflow:   event.Workflow,
+		RunID:      event.RunID,
+		EventType:  string(event.Type),
+		RunURL:     event.URL,
+		Timestamp:  now,
+		Duration:   event.Duration.Round(time.Second),
+		ETA:        event.ETA,
+		Remaining:  remaining,
+		Runs:       event.Runs,
+		Commit:     event.Commit,
+		Outputs:    event.Outputs,
+		Digest:     event.Digest,
+	}
+	if event.Summary != nil {
+		data.Summary = *event.Summary
+	}
+	return data
+}
+
+// renderTemplate executes a text template against the event
+func renderTemplate(name, text string, event model.WorkflowEvent) (string, error) {
+	tmpl, err := template.New(name).Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return "", goerr.Wrap(err, "failed to parse template", goerr.V("name", name))
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, newTemplateData(event, time.Now())); err != nil {
+		return "", goerr.Wrap(err, "failed to execute template", goerr.V("name", name))
+	}
+
+	return buf.String(), nil
+}
+
+// renderField renders a configuration value such as a URL, path or command
+// argument. Environment variables are expanded in the literal text, so values
+// produced by the template are never expanded.
+func renderField(name, text string, event model.WorkflowEvent) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return expandEnvVars(text), nil
+	}
+	return renderTemplate(name, expandTemplateEnv(text), event)
+}
+
+// expandTemplateEnv expands environment variables outside of {{ }} actions,
+// leaving template variables such as $run intact
+func expandTemplateEnv(text string) string {
+	var b strings.Builder
+	for {
+		start := strings.Index(text, "{{")
+		if start < 0 {
+			b.WriteString(expandEnvVars(text))
+			return b.String()
+		}
+		end := strings.Index(text[start:], "}}")
+		if end < 0 {
+			// Unterminated action; let the template parser report it
+			b.WriteString(expandEnvVars(text[:start]))
+			b.WriteString(text[start:])
+			return b.String()
+		}
+		end += start + len("}}")
+
+		b.WriteString(expandEnvVars(text[:start]))
+		b.WriteString(text[start:end])
+		text = text[end:]
+	}
+}
+
+// checkTemplate parses text with the template functions without executing it
+func checkTemplate(name, text string) error {
+	if _, err := template.New(name).Funcs(templateFuncs).Parse(text); err != nil {
+		return goerr.Wrap(err, "invalid template", goerr.V("name", name))
+	}
+	return nil
+}
+
+// renderHTMLTemplate executes an HTML template against the event, escaping values for HTML
+func renderHTMLTemplate(name, text string, event model.WorkflowEvent) (string, error) {
+	tmpl, err := htmltemplate.New(name).Funcs(htmltemplate.FuncMap(templateFuncs)).Parse(text)
+	if err != nil {
+		return "", goerr.Wrap(err, "failed to parse template", goerr.V("name", name))
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, newTemplateData(event, time.Now())); err != nil {
+		return "", goerr.Wrap(err, "failed to execute template", goerr.V("name", name))
+	}
+
+	return buf.String(), nil
+}
+
+// renderMessageFields renders the value templates of message fields
+func renderMessageFields(fields []model.MessageField, event model.WorkflowEvent) ([]model.MessageField, error) {
+	rendered := make([]model.MessageField, 0, len(fields))
+	for _, field := range fields {
+		value, err := renderTemplate(field.Name, field.Value, event)
+		if err != nil {
+			return nil, goerr.Wrap(err, "failed to build field", goerr.V("field", field.Name))
+		}
+		rendered = append(rendered, model.MessageField{Name: field.Name, Value: value, Inline: field.Inline})
+	}
+	return rendered, nil
+}
+
+// messageColor returns the configured color, or good/danger/warning derived from the event type
+func messageColor(color string, eventType model.HookEvent) string {
+	if color != "" {
+		return color
+	}
+
+	switch eventType {
+	case model.HookCheckSuccess, model.HookCompleteSuccess, model.HookRecovered:
+		return "good"
+	case model.HookCheckFailure, model.HookCompleteFailure, model.HookFirstFailure, model.HookCheckTimedOut:
+		return "danger"
+	default:
+		return "warning"
+	}
+}
@@ -0,0 +1,89 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m-mizutani/gt"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+	"github.com/m-mizutani/octap/pkg/usecase"
+)
+
+func TestTemplateFunctions(t *testing.T) {
+	event := model.WorkflowEvent{
+		Type:       model.HookCompleteFailure,
+		Repository: "owner/repo",
+		Duration:   3*time.Minute + 5*time.Second,
+		Commit: model.Commit{
+			SHA:    "0123456789abcdef",
+			Branch: "main",
+		},
+		Runs: []*model.WorkflowRun{
+			{Name: "CI", Status: model.WorkflowStatusCompleted, Conclusion: model.WorkflowConclusionFailure},
+			{Name: "Lint", Status: model.WorkflowStatusInProgress},
+			{Name: "E2E", Status: model.WorkflowStatusCompleted, Conclusion: model.WorkflowConclusionTimedOut},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{"shortSHA", "{{shortSHA .Commit.SHA}}", "0123456"},
+		{"duration", "{{duration .Duration}}", "3m 5s"},
+		{"duration in hours", "{{duration 3723000000000}}", "1h 2m"},
+		{"upper", "{{upper .Commit.Branch}}", "MAIN"},
+		{"lower", `{{lower "CI"}}`, "ci"},
+		{"join", `{{join ", " .Runs}}`, "CI, Lint, E2E"},
+		{"emoji of event type", "{{emoji .EventType}}", "❌"},
+		{"emoji of run", "{{range .Runs}}{{emoji .}}{{end}}", "❌🔄⌛"},
+		{"emoji of conclusion", `{{emoji "timed_out"}}`, "⌛"},
+		{"statusEmoji", "{{range .Runs}}{{statusEmoji .}}{{end}}", "❌🔄⌛"},
+		{"default with empty value", `{{.Commit.Author | default "someone"}}`, "someone"},
+		{"default with value", `{{.Commit.Branch | default "unknown"}}`, "main"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := usecase.RenderTemplate(tc.name, tc.template, event)
+			gt.NoError(t, err).Required()
+			gt.Equal(t, result, tc.expected)
+		})
+	}
+}
+
+func TestRenderField(t *testing.T) {
+	t.Setenv("OCTAP_TEST_HOST", "example.com")
+	t.Setenv("OCTAP_TEST_INJECTED", "injected")
+
+	event := model.WorkflowEvent{
+		Type:       model.HookCheckSuccess,
+		Repository: "owner/repo",
+		// A value that looks like an environment variable must not be expanded
+		Workflow: "$OCTAP_TEST_INJECTED",
+	}
+
+	t.Run("plain value expands environment variables", func(t *testing.T) {
+		result, err := usecase.RenderField("url", "https://${OCTAP_TEST_HOST}/hook", event)
+		gt.NoError(t, err).Required()
+		gt.Equal(t, result, "https://example.com/hook")
+	})
+
+	t.Run("template value expands environment variables in literal text only", func(t *testing.T) {
+		result, err := usecase.RenderField("url", "https://$OCTAP_TEST_HOST/{{.Repository}}/{{.Workflow}}", event)
+		gt.NoError(t, err).Required()
+		gt.Equal(t, result, "https://example.com/owner/repo/$OCTAP_TEST_INJECTED")
+	})
+
+	t.Run("template variables are kept", func(t *testing.T) {
+		result, err := usecase.RenderField("args", `{{$repo := .Repository}}{{$repo}}`, event)
+		gt.NoError(t, err).Required()
+		gt.Equal(t, result, "owner/repo")
+	})
+
+	t.Run("syntax error", func(t *testing.T) {
+		_, err := usecase.RenderField("url", "https://{{.Repository", event)
+		gt.Error(t, err)
+	})
+}
@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/m-mizutani/ctxlog"
+	"github.com/m-mizutani/goerr/v2"
+	"github.com/m-mizutani/octap/pkg/domain/interfaces"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+)
+
+// webhookSignatureHeader carries the HMAC-SHA256 signature of the body
+const webhookSignatureHeader = "X-Octap-Signature"
+
+type webhookAction struct {
+	httpClient *http.Client
+}
+
+// NewWebhookAction creates a new WebhookAction instance
+func NewWebhookAction() interfaces.ActionExecutor {
+	return &webhookAction{
+		// Requests are limited by the timeout of the action's attempts
+		httpClient: &http.Client{},
+	}
+}
+
+// Execute sends an HTTP request built from the action configuration
+func (w *webhookAction) Execute(ctx context.Context, action model.Action, event model.WorkflowEvent) error {
+	logger := ctxlog.From(ctx)
+
+	logger.Debug("webhookAction.Execute called",
+		slog.String("event_type", string(event.Type)),
+	)
+
+	webhookAction, err := action.ToWebhookAction()
+	if err != nil {
+		logger.Error("Failed to parse webhook action",
+			slog.String("error", err.Error()),
+		)
+		return goerr.Wrap(err, "failed to parse webhook action")
+	}
+
+	url, err := renderField("url", webhookAction.URL, event)
+	if err != nil {
+		return goerr.Wrap(err, "failed to build webhook URL")
+	}
+	if url == "" {
+		return goerr.New("webhook URL is empty after expansion")
+	}
+
+	body, err := w.buildBody(webhookAction.Body, event)
+	if err != nil {
+		return err
+	}
+
+	headers := make(http.Header)
+	if webhookAction.Body == "" {
+		headers.Set("Content-Type", "application/json")
+	}
+	for key, value := range webhookAction.Headers {
+		rendered, err := renderField(key, value, event)
+		if err != nil {
+			return goerr.Wrap(err, "failed to build webhook header", goerr.V("header", key))
+		}
+		headers.Set(key, rendered)
+	}
+	if webhookAction.Secret != "" {
+		headers.Set(webhookSignatureHeader, signBody(expandEnvVars(webhookAction.Secret), body))
+	}
+
+	err = sendRequest(ctx, w.httpClient, webhookAction.Method, url, headers, body)
+	if err != nil {
+		return goerr.Wrap(err, "failed to send webhook", goerr.V("url", maskWebhookURL(url)))
+	}
+
+	logger.Debug("Webhook sent successfully",
+		slog.String("url", maskWebhookURL(url)),
+	)
+	return nil
+}
+
+// buildBody renders the body template, or encodes the event as JSON when there is none
+func (w *webhookAction) buildBody(bodyTemplate string, event model.WorkflowEvent) ([]byte, error) {
+	if bodyTemplate != "" {
+		body, err := renderTemplate("body", bodyTemplate, event)
+		if err != nil {
+			return nil, goerr.Wrap(err, "failed to build webhook body")
+		}
+		return []byte(body), nil
+	}
+
+	body, err := json.Marshal(newEventPayload(event, time.Now()))
+	if err != nil {
+		return nil, goerr.Wrap(err, "failed to marshal webhook payload")
+	}
+	return body, nil
+}
+
+// signBody returns the HMAC-SHA256 signature of body in the "sha256=<hex>" format
+func signBody(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
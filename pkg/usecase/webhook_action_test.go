@@ -0,0 +1,96 @@
+package usecase_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/m-mizutani/gt"
+	"github.com/m-mizutani/octap/pkg/domain/model"
+	"github.com/m-mizutani/octap/pkg/usecase"
+)
+
+func TestWebhookAction(t *testing.T) {
+	event := model.WorkflowEvent{
+		Type:       model.HookCheckFailure,
+		Repository: "owner/repo",
+		Workflow:   `say "hi"`,
+		RunID:      42,
+		URL:        "https://github.com/owner/repo/actions/runs/42",
+	}
+
+	t.Run("templated body with headers and signature", func(t *testing.T) {
+		t.Setenv("TEST_WEBHOOK_TOKEN", "secret-token")
+
+		var received []byte
+		var header http.Header
+		var method string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			method = r.Method
+			header = r.Header
+			received, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		action := model.Action{
+			Type: "webhook",
+			Data: map[string]interface{}{
+				"url":    server.URL,
+				"method": "put",
+				"headers": map[string]interface{}{
+					"Authorization": "Bearer ${TEST_WEBHOOK_TOKEN}",
+					"Content-Type":  "application/json",
+				},
+				"body":   `{"workflow": {{json .Workflow}}, "run": {{.RunID}}}`,
+				"secret": "shh",
+			},
+		}
+
+		err := usecase.NewWebhookAction().Execute(context.Background(), action, event)
+		gt.NoError(t, err)
+		gt.Equal(t, method, http.MethodPut)
+		gt.Equal(t, header.Get("Authorization"), "Bearer secret-token")
+
+		var body map[string]any
+		gt.NoError(t, json.Unmarshal(received, &body))
+		gt.Equal(t, body["workflow"], any(`say "hi"`))
+
+		mac := hmac.New(sha256.New, []byte("shh"))
+		mac.Write(received)
+		gt.Equal(t, header.Get("X-Octap-Signature"), "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	})
+
+	t.Run("default body is the event as JSON", func(t *testing.T) {
+		var received map[string]any
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gt.Equal(t, r.Header.Get("Content-Type"), "application/json")
+			gt.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		}))
+		defer server.Close()
+
+		action := model.Action{Type: "webhook", Data: map[string]interface{}{"url": server.URL}}
+		gt.NoError(t, usecase.NewWebhookAction().Execute(context.Background(), action, event))
+		gt.Equal(t, received["event_type"], any("check_failure"))
+		gt.Equal(t, received["run_url"], any(event.URL))
+	})
+	t.Run("sends once and leaves retries to the hook executor", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		action := model.Action{Type: "webhook", Data: map[string]interface{}{"url": server.URL}}
+		gt.Error(t, usecase.NewWebhookAction().Execute(context.Background(), action, event))
+		gt.Equal(t, calls.Load(), int32(1))
+	})
+}